@@ -0,0 +1,130 @@
+package appconfig
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ArgRest is a value of `arg` tag for a slice field, which takes all positional arguments after numbered ones
+const ArgRest = "rest"
+
+// argParams returns positional parameters of the command, ordered by position, ArgRest parameter is the last
+func (ci *ConfigInfo) argParams(command string) []*ParamInfo {
+	var result, rest []*ParamInfo
+	for idx := range ci.params {
+		param := &ci.params[idx]
+		if param.Arg == "" || param.Command != command {
+			continue
+		}
+		if param.Arg == ArgRest {
+			rest = append(rest, param)
+		} else {
+			result = append(result, param)
+		}
+	}
+	slices.SortStableFunc(result, func(a, b *ParamInfo) int { return argPosition(a) - argPosition(b) })
+	return append(result, rest...)
+}
+
+func argPosition(param *ParamInfo) int {
+	pos, err := strconv.Atoi(param.Arg)
+	if err != nil {
+		return -1
+	}
+	return pos
+}
+
+// checkArgParams checks `arg` tags: positions of every command are numbered from 0 without gaps,
+// only one slice parameter takes the rest of arguments
+func (ci *ConfigInfo) checkArgParams() error {
+	var errs []error
+	for _, command := range append([]string{""}, commandNames(ci.commands)...) {
+		params := ci.argParams(command)
+		for pos, param := range params {
+			switch {
+			case param.Arg == ArgRest:
+				if param.Kind() != reflect.Slice {
+					errs = append(errs, fmt.Errorf("parameter %s with `arg:\"%s\"` tag must be a slice", param.Path, ArgRest))
+				}
+				if pos != len(params)-1 {
+					errs = append(errs, fmt.Errorf("parameter %s: only one parameter can take the rest of arguments", param.Path))
+				}
+			case argPosition(param) != pos:
+				errs = append(errs, fmt.Errorf("parameter %s: invalid position `%s` of argument, expected %d", param.Path, param.Arg, pos))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func commandNames(commands []CommandInfo) []string {
+	result := make([]string, 0, len(commands))
+	for _, command := range commands {
+		result = append(result, command.Name)
+	}
+	return result
+}
+
+// checkArgs checks that positional arguments are expected by the selected command
+func (ci *ConfigInfo) checkArgs(args []string) error {
+	params := ci.argParams(ci.command)
+	if len(params) == 0 || params[len(params)-1].Arg == ArgRest || len(args) <= len(params) {
+		return nil
+	}
+	return fmt.Errorf("unexpected argument `%s`", args[len(params)])
+}
+
+// loadRestArgs loads positional arguments, which are not taken by numbered parameters, into the slice field
+func (ci *ConfigInfo) loadRestArgs(param *ParamInfo, field reflect.Value, args []string) error {
+	first := len(ci.argParams(param.Command)) - 1
+	if first >= len(args) {
+		return nil
+	}
+	values := reflect.MakeSlice(field.Type(), len(args)-first, len(args)-first)
+	for idx, value := range args[first:] {
+		if err := ci.setRawValue(param, values.Index(idx), value); err != nil {
+			return newFieldError(fieldErrorParse, param, LoadSourceFlags, value, err)
+		}
+	}
+	field.Set(values)
+	param.Source = LoadSourceFlags
+	return nil
+}
+
+// usage returns usage line for the selected command, e.g. "Usage: my-app serve [flags] INPUT [OUTPUT]",
+// empty if configuration has neither commands nor positional arguments
+func (ci *ConfigInfo) usage() string {
+	params := ci.argParams(ci.command)
+	if len(ci.commands) == 0 && len(params) == 0 {
+		return ""
+	}
+
+	parts := []string{"Usage:", filepath.Base(os.Args[0])}
+	if ci.command != "" {
+		parts = append(parts, ci.command)
+	}
+	parts = append(parts, "[flags]")
+	if len(ci.subcommands(ci.command)) > 0 {
+		parts = append(parts, "COMMAND")
+		if len(params) > 0 {
+			parts = append(parts, "|")
+		}
+	}
+	for _, param := range params {
+		name := param.ArgName
+		if param.Arg == ArgRest {
+			name += "..."
+		}
+		if !param.Required {
+			name = "[" + name + "]"
+		}
+		parts = append(parts, name)
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,169 @@
+package appconfig
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type argsTestCfg struct {
+	ConfigBase
+	Verbose bool
+	Input   string `arg:"0" required:"true" help:"file to read"`
+	Output  string `arg:"1" default:"out.txt"`
+	Extra   []int  `arg:"rest"`
+	Convert struct {
+		Format string `arg:"0"`
+	} `cmd:"convert"`
+}
+
+func TestConfigInfo_Args(t *testing.T) {
+	t.Parallel()
+	ci, err := NewConfigInfo(&argsTestCfg{}, "APP")
+	require.NoError(t, err)
+	param, ok := ci.Params().ByPath("Input")
+	require.True(t, ok)
+	assert.Equal(t, "0", param.Arg)
+	assert.Equal(t, "INPUT", param.ArgName)
+	assert.Empty(t, param.FlagName)
+	assert.Equal(t, "APP_INPUT", param.EnvName)
+
+	_, err = NewConfigInfo(&struct {
+		A string `arg:"1"`
+		B string `arg:"rest"`
+		C []int  `arg:"rest"`
+	}{}, "")
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "A: invalid position `1` of argument, expected 0")
+	assert.ErrorContains(t, err, "B with `arg:\"rest\"` tag must be a slice")
+	assert.ErrorContains(t, err, "only one parameter can take the rest of arguments")
+}
+
+func TestConfigInfo_LoadArgs(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		args     []string
+		env      map[string]string
+		expected argsTestCfg
+		wantErr  string
+	}{
+		{
+			name:     "required only",
+			args:     []string{"in.txt", "--verbose"},
+			expected: argsTestCfg{Verbose: true, Input: "in.txt", Output: "out.txt"},
+		},
+		{
+			name:     "all arguments",
+			args:     []string{"in.txt", "res.txt", "1", "2", "--verbose=false"},
+			expected: argsTestCfg{Input: "in.txt", Output: "res.txt", Extra: []int{1, 2}},
+		},
+		{
+			name:     "arguments after double dash",
+			args:     []string{"--verbose", "--", "--in.txt"},
+			expected: argsTestCfg{Verbose: true, Input: "--in.txt", Output: "out.txt"},
+		},
+		{
+			name:     "argument from environment",
+			env:      map[string]string{"APP_INPUT": "env.txt"},
+			expected: argsTestCfg{Input: "env.txt", Output: "out.txt"},
+		},
+		{
+			name:    "missing required argument",
+			args:    []string{"--verbose"},
+			wantErr: "invalid value of Input: value is required",
+		},
+		{
+			name:    "invalid value of the rest",
+			args:    []string{"in.txt", "res.txt", "1", "x"},
+			wantErr: "can't parse flag value `x` for Extra",
+		},
+		{
+			name: "command arguments",
+			args: []string{"convert", "json"},
+			expected: argsTestCfg{Convert: struct {
+				Format string `arg:"0"`
+			}{Format: "json"}},
+		},
+		{
+			name:    "unexpected argument of command",
+			args:    []string{"convert", "json", "yaml"},
+			wantErr: "unexpected argument `yaml`",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := &argsTestCfg{}
+			ci, err := NewConfigInfo(cfg, "APP")
+			require.NoError(t, err)
+			ci.SetArgs(tt.args)
+			ci.SetEnv(tt.env)
+
+			err = ci.Load(cfg)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, &tt.expected, cfg)
+		})
+	}
+}
+
+func TestConfigInfo_ArgsHelp(t *testing.T) {
+	t.Parallel()
+	ci, err := NewConfigInfo(&argsTestCfg{}, "APP")
+	require.NoError(t, err)
+
+	sb := strings.Builder{}
+	require.NoError(t, ci.WriteHelp(&sb, HelpOptions{Width: 80}))
+	assert.Regexp(t, `^Usage: \S+ \[flags\] COMMAND \| INPUT \[OUTPUT\] \[EXTRA\.\.\.\]\n`, sb.String())
+	assert.Regexp(t, `APP_INPUT\s+INPUT\s+file to read`, sb.String())
+
+	require.NoError(t, ci.SetCommand("convert"))
+	sb.Reset()
+	require.NoError(t, ci.WriteHelp(&sb, HelpOptions{Usage: "Usage: custom"}))
+	assert.True(t, strings.HasPrefix(sb.String(), "Usage: custom\n"))
+	sb.Reset()
+	require.NoError(t, ci.WriteHelp(&sb, HelpOptions{}))
+	assert.Regexp(t, `^Usage: \S+ convert \[flags\] \[FORMAT\]\n`, sb.String())
+}
+
+func TestConfigInfo_ArgsFlagsExample(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{
+			name:     "global arguments",
+			args:     []string{"--verbose", "--", "in.txt", "-out.txt", "1", "2"},
+			expected: " \\\n  --verbose=true \\\n  -- \\\n  in.txt \\\n  -out.txt \\\n  1 \\\n  2\n",
+		},
+		{
+			name:     "command",
+			args:     []string{"convert", "json"},
+			expected: " convert \\\n  --verbose=false \\\n  json\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := &argsTestCfg{}
+			ci, err := NewConfigInfo(cfg, "APP")
+			require.NoError(t, err)
+			ci.SetArgs(tt.args)
+			ci.SetEnv(nil)
+			require.NoError(t, ci.LoadInOrder(cfg, LoadSourceFlags))
+
+			sb := strings.Builder{}
+			require.NoError(t, ci.WriteExampleFormat(&sb, cfg, ExampleFormatFlags))
+			assert.True(t, strings.HasSuffix(sb.String(), tt.expected), sb.String())
+			assert.Equal(t, 1, strings.Count(sb.String(), "\n  --verbose"))
+		})
+	}
+}
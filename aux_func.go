@@ -2,6 +2,7 @@ package appconfig
 
 import (
 	"reflect"
+	"strings"
 )
 
 func addPrefix(name string, prefix string, separator string) string {
@@ -22,3 +23,42 @@ func getTagOrName(tag string, field *reflect.StructField) string {
 		return result
 	}
 }
+
+// getFileKey returns the key of the field in yaml-file, following the gopkg.in/yaml.v3 rules:
+// name from `yaml` tag or lowercased field name, "-" for skipped fields, and inline-flag
+func getFileKey(field *reflect.StructField) (key string, inline bool) {
+	name, opts, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+	for _, opt := range strings.Split(opts, ",") {
+		if opt == "inline" {
+			return "", true
+		}
+	}
+	switch name {
+	case "":
+		return strings.ToLower(field.Name), false
+	case "-":
+		return "", false
+	default:
+		return name, false
+	}
+}
+
+// isTagEnabled checks that the tag is set to any value other than empty or boolean false
+func isTagEnabled(tag string, field *reflect.StructField) bool {
+	value := field.Tag.Get(tag)
+	if value == "" {
+		return false
+	}
+	if boolValue, err := parseBool(value); err == nil {
+		return boolValue
+	}
+	return true
+}
+
+// splitTagList splits space-separated tag value, returns nil for empty value
+func splitTagList(value string) []string {
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+	return strings.Fields(value)
+}
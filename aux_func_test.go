@@ -138,3 +138,54 @@ func TestGetTagOrName(t *testing.T) {
 		})
 	}
 }
+
+func TestGetFileKey(t *testing.T) {
+	t.Parallel()
+	type testStruct struct {
+		Field1 string
+		Field2 string `yaml:"custom"`
+		Field3 string `yaml:"-"`
+		Field4 string `yaml:",inline"`
+		Field5 string `yaml:",omitempty"`
+	}
+	rt := reflect.TypeOf(testStruct{})
+
+	tests := []struct {
+		field      int
+		wantKey    string
+		wantInline bool
+	}{
+		{0, "field1", false},
+		{1, "custom", false},
+		{2, "", false},
+		{3, "", true},
+		{4, "field5", false},
+	}
+
+	for _, tt := range tests {
+		field := rt.Field(tt.field)
+		t.Run(field.Name, func(t *testing.T) {
+			t.Parallel()
+			key, inline := getFileKey(&field)
+			assert.Equal(t, tt.wantKey, key)
+			assert.Equal(t, tt.wantInline, inline)
+		})
+	}
+}
+
+func TestIsTagEnabled(t *testing.T) {
+	t.Parallel()
+	type testStruct struct {
+		Field1 string `test:"true"`
+		Field2 string `test:"off"`
+		Field3 string `test:"+"`
+		Field4 string
+	}
+	rt := reflect.TypeOf(testStruct{})
+
+	expected := []bool{true, false, true, false}
+	for idx, want := range expected {
+		field := rt.Field(idx)
+		assert.Equal(t, want, isTagEnabled("test", &field), field.Name)
+	}
+}
@@ -0,0 +1,118 @@
+package appconfig
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Commands returns a copy of the subcommands list in order of the fields declaration
+func (ci *ConfigInfo) Commands() []CommandInfo {
+	return slices.Clone(ci.commands)
+}
+
+// Command returns full name of the command, selected by command-line arguments during loading, e.g. "db migrate",
+// empty if no command is selected
+func (ci *ConfigInfo) Command() string {
+	return ci.command
+}
+
+// SetCommand selects the command, used by Validate and help, without loading
+//   - command - full name of the command, e.g. "db migrate", empty for global parameters only
+func (ci *ConfigInfo) SetCommand(command string) error {
+	if command != "" && !ci.hasCommand(command) {
+		return fmt.Errorf("unknown command `%s`", command)
+	}
+	ci.command = command
+	return nil
+}
+
+// isCommandSelected checks that parameters of the command are used: the command is selected or is its parent,
+// global parameters are always used
+func (ci *ConfigInfo) isCommandSelected(command string) bool {
+	return isSubcommand(ci.command, command)
+}
+
+// isSubcommand checks that the command is the parent or the same command, empty parent is the root of all commands
+func isSubcommand(command, parent string) bool {
+	return parent == "" || command == parent || strings.HasPrefix(command, parent+" ")
+}
+
+func (ci *ConfigInfo) hasCommand(command string) bool {
+	return slices.ContainsFunc(ci.commands, func(c CommandInfo) bool { return c.Name == command })
+}
+
+// isParamSelected checks that the parameter is used by the selected command,
+// positional arguments are used by their own command only
+func (ci *ConfigInfo) isParamSelected(param *ParamInfo) bool {
+	return ci.isCommandSelected(param.Command) && (param.Arg == "" || param.Command == ci.command)
+}
+
+// subcommands returns commands, nested directly into the command, or root commands for empty name
+func (ci *ConfigInfo) subcommands(command string) []CommandInfo {
+	var result []CommandInfo
+	for _, c := range ci.commands {
+		parent := ""
+		if pos := strings.LastIndex(c.Name, " "); pos >= 0 {
+			parent = c.Name[:pos]
+		}
+		if parent == command {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// parseCommandLine selects the command by names in `args`, parses flags by commands and collects positional arguments.
+// Flags, given after the command name, belong to the command, while parameters of its parent commands
+// and global parameters take flags from all arguments. Arguments after "--" or after the first positional one
+// are positional.
+func (ci *ConfigInfo) parseCommandLine(args []string) (command string, flags map[string]map[string]string, positional []string, err error) {
+	flags = map[string]map[string]string{"": {}}
+	scopes := []string{""}
+	for idx, arg := range args {
+		if arg == "--" {
+			positional = append(positional, args[idx+1:]...)
+			break
+		}
+		if strings.HasPrefix(arg, "-") {
+			for key, value := range parseFlags([]string{arg}) {
+				for _, scope := range scopes {
+					flags[scope][key] = value
+				}
+			}
+			continue
+		}
+		if len(positional) > 0 || !ci.hasCommand(addPrefix(arg, command, " ")) {
+			if len(ci.subcommands(command)) > 0 && len(ci.argParams(command)) == 0 {
+				return "", nil, nil, fmt.Errorf("unknown command `%s`", addPrefix(arg, command, " "))
+			}
+			positional = append(positional, arg)
+			continue
+		}
+		command = addPrefix(arg, command, " ")
+		scopes = append(scopes, command)
+		flags[command] = map[string]string{}
+	}
+
+	return command, flags, positional, nil
+}
+
+// loadCommandLine selects the command by command-line arguments, returns flags by commands,
+// merged with values parsed by the bound flag set, and positional arguments
+func (ci *ConfigInfo) loadCommandLine() (map[string]map[string]string, []string, error) {
+	command, flags, args, err := ci.parseCommandLine(ci.flagArgs())
+	if err != nil {
+		return nil, nil, err
+	}
+	ci.command = command
+	for name, value := range ci.flagSetValues {
+		if _, found := flags[""][name]; !found {
+			flags[""][name] = value
+		}
+	}
+	if err = ci.checkArgs(args); err != nil {
+		return nil, nil, err
+	}
+	return flags, args, nil
+}
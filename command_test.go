@@ -0,0 +1,211 @@
+package appconfig
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type commandTestCfg struct {
+	ConfigBase
+	Verbose bool
+	Serve   struct {
+		Port int `default:"8080"`
+		TLS  struct {
+			Cert string
+		}
+	} `cmd:"serve" help:"run server"`
+	DB struct {
+		DSN     string `required:"true"`
+		Migrate struct {
+			Steps int
+		} `cmd:"migrate" help:"apply migrations"`
+	} `cmd:"db" help:"database tools"`
+}
+
+func TestConfigInfo_Commands(t *testing.T) {
+	t.Parallel()
+	ci, err := NewConfigInfo(&commandTestCfg{}, "APP")
+	require.NoError(t, err)
+
+	assert.Equal(t, []CommandInfo{
+		{Name: "serve", Path: "Serve", HelpText: "run server"},
+		{Name: "db", Path: "DB", HelpText: "database tools"},
+		{Name: "db migrate", Path: "DB.Migrate", HelpText: "apply migrations"},
+	}, ci.Commands())
+
+	params := ci.Params()
+	tests := []struct {
+		path     string
+		envName  string
+		flagName string
+		command  string
+	}{
+		{path: "Verbose", envName: "APP_VERBOSE", flagName: "--verbose"},
+		{path: "Serve.Port", envName: "APP_SERVE_PORT", flagName: "--port", command: "serve"},
+		{path: "Serve.TLS.Cert", envName: "APP_SERVE_TLS_CERT", flagName: "--tls-cert", command: "serve"},
+		{path: "DB.Migrate.Steps", envName: "APP_DB_MIGRATE_STEPS", flagName: "--steps", command: "db migrate"},
+	}
+	for _, tt := range tests {
+		param, ok := params.ByPath(tt.path)
+		require.True(t, ok, tt.path)
+		assert.Equal(t, tt.envName, param.EnvName, tt.path)
+		assert.Equal(t, tt.flagName, param.FlagName, tt.path)
+		assert.Equal(t, tt.command, param.Command, tt.path)
+	}
+
+	require.Error(t, ci.SetCommand("deploy"))
+	require.NoError(t, ci.SetCommand("db migrate"))
+	assert.Equal(t, "db migrate", ci.Command())
+}
+
+func TestConfigInfo_LoadCommand(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		args     []string
+		env      map[string]string
+		command  string
+		expected func(cfg *commandTestCfg)
+		wantErr  string
+	}{
+		{
+			name:     "no command",
+			args:     []string{"--verbose", "--port=90"},
+			expected: func(cfg *commandTestCfg) { cfg.Verbose = true },
+		},
+		{
+			name:    "command with flags",
+			args:    []string{"--verbose", "serve", "--port=90", "--tls-cert=a.pem"},
+			command: "serve",
+			expected: func(cfg *commandTestCfg) {
+				cfg.Verbose = true
+				cfg.Serve.Port = 90
+				cfg.Serve.TLS.Cert = "a.pem"
+			},
+		},
+		{
+			name:    "global flag after command and defaults of command",
+			args:    []string{"serve", "--verbose"},
+			command: "serve",
+			expected: func(cfg *commandTestCfg) {
+				cfg.Verbose = true
+				cfg.Serve.Port = 8080
+			},
+		},
+		{
+			name:    "nested command",
+			args:    []string{"db", "migrate", "--steps=3", "--dsn=pg"},
+			env:     map[string]string{"APP_SERVE_PORT": "90"},
+			command: "db migrate",
+			expected: func(cfg *commandTestCfg) {
+				cfg.DB.DSN = "pg"
+				cfg.DB.Migrate.Steps = 3
+			},
+		},
+		{
+			name:    "flag of command before its name",
+			args:    []string{"--steps=3", "db", "migrate", "--dsn=pg"},
+			command: "db migrate",
+			expected: func(cfg *commandTestCfg) {
+				cfg.DB.DSN = "pg"
+			},
+		},
+		{
+			name:    "required parameter of command",
+			args:    []string{"db"},
+			wantErr: "invalid value of DB.DSN: value is required",
+		},
+		{
+			name:    "unknown command",
+			args:    []string{"deploy"},
+			wantErr: "unknown command `deploy`",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := &commandTestCfg{}
+			ci, err := NewConfigInfo(cfg, "APP")
+			require.NoError(t, err)
+			ci.SetArgs(tt.args)
+			ci.SetEnv(tt.env)
+
+			err = ci.Load(cfg)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.command, ci.Command())
+			expected := &commandTestCfg{}
+			tt.expected(expected)
+			assert.Equal(t, expected, cfg)
+		})
+	}
+}
+
+func TestConfigInfo_CommandHelp(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		args       []string
+		command    string
+		contains   []string
+		notContain []string
+	}{
+		{
+			name:       "root",
+			args:       []string{"--help"},
+			contains:   []string{"--verbose", "List of commands", "serve  run server", "db     database tools"},
+			notContain: []string{"--port", "--dsn", "db migrate"},
+		},
+		{
+			name:       "command",
+			args:       []string{"serve", "--help"},
+			command:    "serve",
+			contains:   []string{"--verbose", "--port", "Serve.TLS"},
+			notContain: []string{"--dsn", "List of commands"},
+		},
+		{
+			name:       "command with subcommands",
+			args:       []string{"db", "--help"},
+			command:    "db",
+			contains:   []string{"--verbose", "--dsn", "db migrate  apply migrations"},
+			notContain: []string{"--port", "--steps"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			out := bytes.Buffer{}
+			command, err := NewLoader(WithArgs(tt.args), WithEnv(nil), WithOutput(&out)).LoadCommand(context.Background(), &commandTestCfg{})
+			require.ErrorIs(t, err, ErrHelpShown)
+			assert.Equal(t, tt.command, command)
+			for _, text := range tt.contains {
+				assert.Contains(t, out.String(), text)
+			}
+			for _, text := range tt.notContain {
+				assert.NotContains(t, out.String(), text)
+			}
+		})
+	}
+}
+
+func TestConfigInfo_LoadInOrderWithoutFlags(t *testing.T) {
+	t.Parallel()
+	cfg := &commandTestCfg{}
+	ci, err := NewConfigInfo(cfg, "APP")
+	require.NoError(t, err)
+	ci.SetArgs([]string{"deploy", "--port=90"})
+	require.NoError(t, ci.SetCommand("serve"))
+
+	require.NoError(t, ci.LoadInOrder(cfg, LoadSourceDefaults))
+	assert.Equal(t, "serve", ci.Command())
+	assert.Equal(t, 8080, cfg.Serve.Port)
+
+	require.ErrorContains(t, ci.LoadInOrder(cfg, LoadSourceDefaults, LoadSourceFlags), "unknown command `deploy`")
+}
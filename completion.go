@@ -0,0 +1,294 @@
+package appconfig
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// HasCompletionFlag checks that the shell completion script is requested
+func (ci *ConfigInfo) HasCompletionFlag() bool {
+	return ci.completionParamValue != ""
+}
+
+// CompletionShell returns the name of shell for which the completion script is requested
+func (ci *ConfigInfo) CompletionShell() string {
+	return ci.completionParamValue
+}
+
+// ShowCompletion prints completion script for the current program to stdout or writer, set by SetOutput
+//   - shell - one of "bash", "zsh" or "fish"
+func (ci *ConfigInfo) ShowCompletion(shell string) error {
+	return ci.WriteCompletion(ci.writer(), shell, filepath.Base(os.Args[0]))
+}
+
+// WriteCompletion writes shell completion script for command-line flags of the program.
+// Values are completed from `oneof` tags, as true/false for bool flags and as file paths for config file flag.
+// Names of subcommands are completed too, flags of a command are completed after its name.
+//   - shell - one of "bash", "zsh" or "fish"
+//   - program - name of the program executable
+func (ci *ConfigInfo) WriteCompletion(w io.Writer, shell string, program string) error {
+	var script string
+	switch shell {
+	case "bash":
+		script = ci.bashCompletion(program)
+	case "zsh":
+		script = ci.zshCompletion(program)
+	case "fish":
+		script = ci.fishCompletion(program)
+	default:
+		return fmt.Errorf("unsupported shell for completion: %q", shell)
+	}
+
+	_, err := io.WriteString(w, script)
+	return err
+}
+
+type completionValues byte
+
+const (
+	completeAny completionValues = iota
+	completeBool
+	completeList
+	completeFile
+)
+
+type completionFlag struct {
+	name    string // flag name without leading "--"
+	help    string
+	kind    completionValues
+	values  []string
+	command string // full name of the command of the flag, empty for global flags
+}
+
+func (ci *ConfigInfo) completionFlags() []completionFlag {
+	var result []completionFlag
+	for idx := range ci.params {
+		param := &ci.params[idx]
+		if param.FlagName == "" {
+			continue
+		}
+		flag := completionFlag{
+			name:    strings.TrimPrefix(param.FlagName, "--"),
+			help:    param.HelpText,
+			command: param.Command,
+		}
+		switch {
+		case len(param.Enum) > 0:
+			flag.kind, flag.values = completeList, param.Enum
+		case param.Kind() == reflect.Bool:
+			flag.kind, flag.values = completeBool, []string{"true", "false"}
+		case idx+1 == ci.configNameParamNumber || idx+1 == ci.envFileParamNumber:
+			flag.kind = completeFile
+		}
+		result = append(result, flag)
+	}
+	return result
+}
+
+// commandFlags returns flags, which can be given after names of the command: global, of the command and its parents
+func commandFlags(flags []completionFlag, command string) []completionFlag {
+	var result []completionFlag
+	for _, flag := range flags {
+		if isSubcommand(command, flag.command) {
+			result = append(result, flag)
+		}
+	}
+	return result
+}
+
+// subcommandWords returns last words of names of subcommands, nested directly into the command
+func (ci *ConfigInfo) subcommandWords(command string) []string {
+	var result []string
+	for _, c := range ci.subcommands(command) {
+		result = append(result, c.Name[strings.LastIndex(c.Name, " ")+1:])
+	}
+	return result
+}
+
+// quotedCommandNames returns names of all commands, quoted for shell pattern, e.g. `"db migrate"`
+func (ci *ConfigInfo) quotedCommandNames(sep string) string {
+	names := make([]string, 0, len(ci.commands))
+	for _, c := range ci.commands {
+		names = append(names, `"`+c.Name+`"`)
+	}
+	return strings.Join(names, sep)
+}
+
+var notIdentifierChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+func (ci *ConfigInfo) bashCompletion(program string) string {
+	funcName := "_" + notIdentifierChars.ReplaceAllString(program, "_") + "_completion"
+	flags := ci.completionFlags()
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# bash completion for %s\n", program)
+	fmt.Fprintf(&sb, "%s() {\n", funcName)
+	sb.WriteString("    local line=\"${COMP_LINE:0:COMP_POINT}\"\n")
+	sb.WriteString("    local cur=\"${line##* }\"\n")
+	sb.WriteString("    case \"$cur\" in\n")
+	for _, flag := range flags {
+		switch flag.kind {
+		case completeList, completeBool:
+			fmt.Fprintf(&sb, "        --%s=*) COMPREPLY=($(compgen -W %q -- \"${cur#*=}\")); return ;;\n",
+				flag.name, strings.Join(flag.values, " "))
+		case completeFile:
+			fmt.Fprintf(&sb, "        --%s=*) COMPREPLY=($(compgen -f -- \"${cur#*=}\")); return ;;\n", flag.name)
+		case completeAny:
+			fmt.Fprintf(&sb, "        --%s=*) COMPREPLY=(); return ;;\n", flag.name)
+		}
+	}
+	sb.WriteString("    esac\n")
+	if len(ci.commands) == 0 {
+		fmt.Fprintf(&sb, "    COMPREPLY=($(compgen -W %q -- \"$cur\"))\n", bashWords(flags, nil))
+	} else {
+		// the command is selected by known names among previous words
+		sb.WriteString("    local cmd=\"\" word words=(${line% *})\n")
+		sb.WriteString("    for word in \"${words[@]:1}\"; do\n")
+		sb.WriteString("        case \"${cmd:+$cmd }$word\" in\n")
+		fmt.Fprintf(&sb, "            %s) cmd=\"${cmd:+$cmd }$word\" ;;\n", ci.quotedCommandNames("|"))
+		sb.WriteString("        esac\n")
+		sb.WriteString("    done\n")
+		sb.WriteString("    case \"$cmd\" in\n")
+		for _, command := range append([]string{""}, commandNames(ci.commands)...) {
+			fmt.Fprintf(&sb, "        %q) COMPREPLY=($(compgen -W %q -- \"$cur\")) ;;\n",
+				command, bashWords(commandFlags(flags, command), ci.subcommandWords(command)))
+		}
+		sb.WriteString("    esac\n")
+	}
+	sb.WriteString("    if [[ ${#COMPREPLY[@]} -eq 1 && ${COMPREPLY[0]} == *= ]]; then\n")
+	sb.WriteString("        compopt -o nospace\n")
+	sb.WriteString("    fi\n")
+	sb.WriteString("}\n")
+	fmt.Fprintf(&sb, "complete -F %s %s\n", funcName, program)
+
+	return sb.String()
+}
+
+// bashWords returns words for completion: flag names, with "=" for flags with value, and names of commands
+func bashWords(flags []completionFlag, commands []string) string {
+	words := make([]string, 0, len(flags)+len(commands))
+	for _, flag := range flags {
+		name := "--" + flag.name
+		if flag.kind != completeBool {
+			name += "="
+		}
+		words = append(words, name)
+	}
+	return strings.Join(append(words, commands...), " ")
+}
+
+func (ci *ConfigInfo) zshCompletion(program string) string {
+	flags := ci.completionFlags()
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "#compdef %s\n\n", program)
+	if len(ci.commands) == 0 {
+		writeZshArguments(&sb, flags, nil, "")
+		return sb.String()
+	}
+
+	// the command is selected by known names among previous words
+	sb.WriteString("local cmd=\"\" word\n")
+	sb.WriteString("for word in ${words[2,CURRENT-1]}; do\n")
+	sb.WriteString("  case \"${cmd:+$cmd }$word\" in\n")
+	fmt.Fprintf(&sb, "    %s) cmd=\"${cmd:+$cmd }$word\" ;;\n", ci.quotedCommandNames("|"))
+	sb.WriteString("  esac\n")
+	sb.WriteString("done\n")
+	sb.WriteString("case \"$cmd\" in\n")
+	for _, command := range append([]string{""}, commandNames(ci.commands)...) {
+		fmt.Fprintf(&sb, "  %q)\n", command)
+		writeZshArguments(&sb, commandFlags(flags, command), ci.subcommandWords(command), "    ")
+		sb.WriteString("    ;;\n")
+	}
+	sb.WriteString("esac\n")
+
+	return sb.String()
+}
+
+// writeZshArguments writes `_arguments` call for flags, positional arguments are completed with names of commands
+func writeZshArguments(sb *strings.Builder, flags []completionFlag, commands []string, indent string) {
+	escape := strings.NewReplacer("'", `'\''`, "[", `\[`, "]", `\]`, ":", `\:`)
+
+	sb.WriteString(indent + "_arguments \\\n")
+	for _, flag := range flags {
+		var action string
+		switch {
+		case flag.kind == completeFile:
+			action = ":file:_files"
+		case flag.kind == completeBool:
+			action = "::value:(true false)"
+		case flag.kind == completeList:
+			action = ":value:(" + escape.Replace(strings.Join(flag.values, " ")) + ")"
+		default:
+			action = ":value: "
+		}
+		fmt.Fprintf(sb, "%s  '--%s=-[%s]%s' \\\n", indent, flag.name, escape.Replace(flag.help), action)
+	}
+	if len(commands) > 0 {
+		fmt.Fprintf(sb, "%s  '*: :(%s)'\n", indent, escape.Replace(strings.Join(commands, " ")))
+	} else {
+		sb.WriteString(indent + "  '*: :'\n")
+	}
+}
+
+func (ci *ConfigInfo) fishCompletion(program string) string {
+	escape := strings.NewReplacer(`\`, `\\`, "'", `\'`)
+
+	funcName := "__" + notIdentifierChars.ReplaceAllString(program, "_") + "_using_command"
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# fish completion for %s\n", program)
+	if len(ci.commands) > 0 {
+		// checks that the command, selected by known names among previous words, is one of arguments
+		fmt.Fprintf(&sb, "function %s\n", funcName)
+		sb.WriteString("    set -l cmd \"\"\n")
+		sb.WriteString("    for word in (commandline -opc)[2..-1]\n")
+		fmt.Fprintf(&sb, "        if contains -- (string trim -- \"$cmd $word\") %s\n", ci.quotedCommandNames(" "))
+		sb.WriteString("            set cmd (string trim -- \"$cmd $word\")\n")
+		sb.WriteString("        end\n")
+		sb.WriteString("    end\n")
+		sb.WriteString("    contains -- \"$cmd\" $argv\n")
+		sb.WriteString("end\n")
+		for _, command := range append([]string{""}, commandNames(ci.commands)...) {
+			for _, c := range ci.subcommands(command) {
+				fmt.Fprintf(&sb, "complete -c %s -f -n '%s \"%s\"' -a %s -d '%s'\n", program, funcName, command,
+					c.Name[strings.LastIndex(c.Name, " ")+1:], escape.Replace(c.HelpText))
+			}
+		}
+	}
+	for _, flag := range ci.completionFlags() {
+		fmt.Fprintf(&sb, "complete -c %s", program)
+		if flag.command != "" {
+			// flags of the command are given after its name or names of its subcommands
+			fmt.Fprintf(&sb, " -n '%s %s'", funcName, ci.commandAndSubcommands(flag.command))
+		}
+		fmt.Fprintf(&sb, " -l %s", flag.name)
+		switch flag.kind {
+		case completeFile:
+			sb.WriteString(" -r -F")
+		case completeList, completeBool:
+			fmt.Fprintf(&sb, " -x -a '%s'", escape.Replace(strings.Join(flag.values, " ")))
+		case completeAny:
+			sb.WriteString(" -x")
+		}
+		fmt.Fprintf(&sb, " -d '%s'\n", escape.Replace(flag.help))
+	}
+
+	return sb.String()
+}
+
+// commandAndSubcommands returns quoted names of the command and all its nested commands
+func (ci *ConfigInfo) commandAndSubcommands(command string) string {
+	var names []string
+	for _, c := range ci.commands {
+		if isSubcommand(c.Name, command) {
+			names = append(names, `"`+c.Name+`"`)
+		}
+	}
+	return strings.Join(names, " ")
+}
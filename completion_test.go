@@ -0,0 +1,137 @@
+package appconfig
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type completionTestCfg struct {
+	ConfigBase `yaml:"-"`
+	Mode       string `oneof:"dev prod" help:"Run mode"`
+	HTTP       struct {
+		Address string `flag:"addr" help:"Address [host:port]"`
+	}
+	Hidden int `flag:"-"`
+}
+
+func TestConfigInfo_WriteCompletion(t *testing.T) {
+	t.Parallel()
+	ci, err := NewConfigInfo(&completionTestCfg{}, "APP")
+	require.NoError(t, err)
+
+	tests := []struct {
+		shell    string
+		contains []string
+	}{
+		{
+			shell: "bash",
+			contains: []string{
+				"_my_app_completion() {\n",
+				`        --mode=*) COMPREPLY=($(compgen -W "dev prod" -- "${cur#*=}")); return ;;`,
+				`        --help=*) COMPREPLY=($(compgen -W "true false" -- "${cur#*=}")); return ;;`,
+				`        --config=*) COMPREPLY=($(compgen -f -- "${cur#*=}")); return ;;`,
+				`        --completion=*) COMPREPLY=($(compgen -W "bash zsh fish" -- "${cur#*=}")); return ;;`,
+				`        --http-addr=*) COMPREPLY=(); return ;;`,
+				`    COMPREPLY=($(compgen -W "--help --example --example-format= --config= --completion= --env-file= --mode= --http-addr=" -- "$cur"))`,
+				"complete -F _my_app_completion my-app\n",
+			},
+		},
+		{
+			shell: "zsh",
+			contains: []string{
+				"#compdef my-app\n",
+				`  '--help=-[show this help]::value:(true false)' \`,
+				`  '--config=-[config file to load]:file:_files' \`,
+				`  '--mode=-[Run mode]:value:(dev prod)' \`,
+				`  '--http-addr=-[Address \[host\:port\]]:value: ' \`,
+			},
+		},
+		{
+			shell: "fish",
+			contains: []string{
+				"complete -c my-app -l help -x -a 'true false' -d 'show this help'\n",
+				"complete -c my-app -l config -r -F -d 'config file to load'\n",
+				"complete -c my-app -l mode -x -a 'dev prod' -d 'Run mode'\n",
+				"complete -c my-app -l http-addr -x -d 'Address [host:port]'\n",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.shell, func(t *testing.T) {
+			t.Parallel()
+			sb := strings.Builder{}
+			require.NoError(t, ci.WriteCompletion(&sb, tt.shell, "my-app"))
+			for _, text := range tt.contains {
+				assert.Contains(t, sb.String(), text)
+			}
+			assert.NotContains(t, sb.String(), "hidden")
+		})
+	}
+
+	require.Error(t, ci.WriteCompletion(&strings.Builder{}, "cmd.exe", "my-app"))
+}
+
+func TestConfigInfo_CompletionFlag(t *testing.T) {
+	t.Parallel()
+	cfg := completionTestCfg{ConfigBase: ConfigBase{Completion: "zsh"}}
+	ci, err := NewConfigInfo(&cfg, "APP")
+	require.NoError(t, err)
+	require.False(t, ci.HasCompletionFlag())
+
+	require.NoError(t, ci.LoadInOrder(&cfg))
+	assert.True(t, ci.HasCompletionFlag())
+	assert.Equal(t, "zsh", ci.CompletionShell())
+}
+
+func TestConfigInfo_WriteCompletionCommands(t *testing.T) {
+	t.Parallel()
+	ci, err := NewConfigInfo(&commandTestCfg{}, "APP")
+	require.NoError(t, err)
+
+	tests := []struct {
+		shell    string
+		contains []string
+	}{
+		{
+			shell: "bash",
+			contains: []string{
+				`            "serve"|"db"|"db migrate") cmd="${cmd:+$cmd }$word" ;;`,
+				`        "") COMPREPLY=($(compgen -W "--help --example --example-format= --config= --completion= --env-file= --verbose serve db" -- "$cur")) ;;`,
+				`        "db") COMPREPLY=($(compgen -W "--help --example --example-format= --config= --completion= --env-file= --verbose --dsn= migrate" -- "$cur")) ;;`,
+				`        --steps=*) COMPREPLY=(); return ;;`,
+			},
+		},
+		{
+			shell: "zsh",
+			contains: []string{
+				"  \"db migrate\")\n    _arguments \\\n",
+				"      '--steps=-[Steps]:value: ' \\\n      '*: :'\n",
+				"      '--verbose=-[Verbose]::value:(true false)' \\\n      '*: :(serve db)'\n",
+			},
+		},
+		{
+			shell: "fish",
+			contains: []string{
+				"function __my_app_using_command\n",
+				"complete -c my-app -f -n '__my_app_using_command \"db\"' -a migrate -d 'apply migrations'\n",
+				"complete -c my-app -l verbose -x -a 'true false' -d 'Verbose'\n",
+				"complete -c my-app -n '__my_app_using_command \"db\" \"db migrate\"' -l dsn -x -d 'DSN'\n",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.shell, func(t *testing.T) {
+			t.Parallel()
+			sb := strings.Builder{}
+			require.NoError(t, ci.WriteCompletion(&sb, tt.shell, "my-app"))
+			for _, text := range tt.contains {
+				assert.Contains(t, sb.String(), text)
+			}
+		})
+	}
+}
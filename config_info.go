@@ -1,24 +1,54 @@
 package appconfig
 
 import (
+	"bytes"
+	"context"
 	"errors"
+	"flag"
 	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
 	"os"
 	"reflect"
 	"slices"
+	"strconv"
 	"strings"
 
 	"gopkg.in/yaml.v3"
 )
 
 type ConfigInfo struct {
-	params                 ParamList
-	helpFlagParamNumber    int
-	helpFlagParamValue     bool
-	exampleFlagParamNumber int
-	exampleFlagParamValue  bool
-	configNameParamNumber  int
-	configNameParamValue   string
+	configType               reflect.Type
+	params                   ParamList
+	sections                 []SectionInfo
+	commands                 []CommandInfo
+	command                  string
+	helpFlagParamNumber      int
+	helpFlagParamValue       bool
+	exampleFlagParamNumber   int
+	exampleFormatParamNumber int
+	exampleFormat            string
+	configNameParamNumber    int
+	configNameParamValue     string
+	completionParamNumber    int
+	completionParamValue     string
+	envFileParamNumber       int
+	envFileParamValue        string
+	interpolation            bool
+	helpOptions              HelpOptions
+	args                     []string
+	lookupEnvFunc            func(name string) (string, bool)
+	fsys                     fs.FS
+	httpOptions              HTTPSourceOptions
+	httpSource               *HTTPSource
+	strict                   bool
+	output                   io.Writer
+	flagSet                  *flag.FlagSet
+	flagSetValues            map[string]string
+	flagSetNames             map[string]string
+	importedFlagSets         []*flag.FlagSet
+	logger                   *slog.Logger
 }
 
 const (
@@ -26,10 +56,17 @@ const (
 	FlagSeparator = "-"
 )
 
+// skippedFileKey marks fields, which are not loaded from config file
+const skippedFileKey = "-"
+
 // NewConfigInfo creates new item on ConfigInfo and fills it with information of config parameters from `config`
 //   - config - any structure or a pointer to it where the configuration is planned to be loaded
 //   - envPrefix - a common prefix for environment variables from which configuration values can be taken
 func NewConfigInfo(config any, envPrefix string) (result *ConfigInfo, err error) {
+	return newConfigInfo(config, envPrefix, DefaultNaming())
+}
+
+func newConfigInfo(config any, envPrefix string, naming NamingStrategy) (result *ConfigInfo, err error) {
 	rv := reflect.ValueOf(config)
 	if rv.Kind() == reflect.Ptr {
 		rv = rv.Elem()
@@ -39,8 +76,14 @@ func NewConfigInfo(config any, envPrefix string) (result *ConfigInfo, err error)
 		return nil, errors.New("value is not a struct or pointer to struct")
 	}
 
-	result = new(ConfigInfo)
-	result.processType(rv.Type(), "", envPrefix, "", nil)
+	result = &ConfigInfo{configType: rv.Type()}
+	if provider, ok := config.(HelpOptionsProvider); ok {
+		result.helpOptions = provider.HelpOptions()
+	}
+	result.processType(rv.Type(), naming, "", "", envPrefix, "", "", nil)
+	if err = result.checkArgParams(); err != nil {
+		return nil, err
+	}
 	for idx := range result.params {
 		if result.params[idx].EnvName != "" {
 			result.params[idx].EnvName = strings.ToUpper(result.params[idx].EnvName)
@@ -48,12 +91,19 @@ func NewConfigInfo(config any, envPrefix string) (result *ConfigInfo, err error)
 		if result.params[idx].FlagName != "" {
 			result.params[idx].FlagName = "--" + strings.ToLower(result.params[idx].FlagName)
 		}
+		for pos := range result.params[idx].Aliases {
+			alias := &result.params[idx].Aliases[pos]
+			alias.EnvName = strings.ToUpper(alias.EnvName)
+			if alias.FlagName != "" {
+				alias.FlagName = "--" + strings.ToLower(alias.FlagName)
+			}
+		}
 	}
 
 	return
 }
 
-func (ci *ConfigInfo) processType(t reflect.Type, pathPrefix string, envPrefix string, flagPrefix string, indexes []int) {
+func (ci *ConfigInfo) processType(t reflect.Type, naming NamingStrategy, command string, pathPrefix string, envPrefix string, flagPrefix string, filePrefix string, indexes []int) {
 fieldsLoop:
 	for i := 0; i < t.NumField(); i++ {
 		field := t.Field(i)
@@ -61,52 +111,131 @@ fieldsLoop:
 			continue // Пропускаем неэкспортируемые поля
 		}
 
+		fileKey, inline := getFileKey(&field)
+		if !inline {
+			fileKey = addPrefix(fileKey, filePrefix, ".")
+			if filePrefix == skippedFileKey || fileKey == "" {
+				fileKey = skippedFileKey
+			}
+		} else {
+			fileKey = filePrefix
+		}
+
 		if field.Type.Kind() == reflect.Struct {
 			subEnvPrefix := envPrefix
 			subFlagPrefix := flagPrefix
 			if !field.Anonymous {
-				subEnvPrefix = addPrefix(toSnakeCase(getTagOrName("env", &field)), envPrefix, EnvSeparator)
-				subFlagPrefix = addPrefix(toKebabCase(getTagOrName("flag", &field)), flagPrefix, FlagSeparator)
+				subEnvPrefix = addPrefix(naming.EnvName(getTagOrName("env", &field)), envPrefix, EnvSeparator)
+				subFlagPrefix = addPrefix(naming.FlagName(getTagOrName("flag", &field)), flagPrefix, FlagSeparator)
 			}
 			subPathPrefix := addPrefix(field.Name, pathPrefix, ".")
-			ci.processType(field.Type, subPathPrefix, subEnvPrefix, subFlagPrefix, append(indexes, field.Index...))
+			subCommand := command
+			if name := field.Tag.Get("cmd"); name != "" {
+				// flags of the command are given after its name, so they are not prefixed
+				subCommand = addPrefix(name, command, " ")
+				subFlagPrefix = ""
+				ci.commands = append(ci.commands, CommandInfo{
+					Name:     subCommand,
+					Path:     subPathPrefix,
+					HelpText: field.Tag.Get("help"),
+				})
+			}
+			si := SectionInfo{
+				Path:      subPathPrefix,
+				FileKey:   fileKey,
+				HelpText:  field.Tag.Get("help"),
+				Anonymous: field.Anonymous,
+				Strict:    isTagEnabled("strict", &field),
+				Command:   subCommand,
+				Default:   field.Tag.Get("default"),
+			}
+			if si.FileKey == skippedFileKey {
+				si.FileKey = ""
+			}
+			ci.sections = append(ci.sections, si)
+			ci.processType(field.Type, naming, subCommand, subPathPrefix, subEnvPrefix, subFlagPrefix, fileKey, slices.Concat(indexes, field.Index))
 
 			continue fieldsLoop
 		}
 
+		if fileKey == skippedFileKey {
+			fileKey = ""
+		}
+
 		pi := ParamInfo{
 			Path:     addPrefix(field.Name, pathPrefix, "."),
-			EnvName:  addPrefix(toSnakeCase(getTagOrName("env", &field)), envPrefix, EnvSeparator),
-			FlagName: addPrefix(toKebabCase(getTagOrName("flag", &field)), flagPrefix, FlagSeparator),
+			EnvName:  addPrefix(naming.EnvName(getTagOrName("env", &field)), envPrefix, EnvSeparator),
+			FlagName: addPrefix(naming.FlagName(getTagOrName("flag", &field)), flagPrefix, FlagSeparator),
+			FileKey:  fileKey,
 			HelpText: getTagOrName("help", &field),
 			Default:  field.Tag.Get("default"),
-			index:    append(indexes, field.Index...),
+			Type:     field.Type,
+			Tag:      field.Tag,
+			Required: isTagEnabled("required", &field),
+			Secret:   isTagEnabled("secret", &field),
+			Enum:     splitTagList(field.Tag.Get("oneof")),
+			Min:      field.Tag.Get("min"),
+			Max:      field.Tag.Get("max"),
+			Command:  command,
+			Arg:      field.Tag.Get("arg"),
+			Source:   LoadSourceNone,
+			index:    slices.Concat(indexes, field.Index),
+		}
+		if pi.Arg != "" {
+			// positional argument replaces the flag
+			pi.ArgName = strings.ToUpper(naming.EnvName(field.Name))
+			pi.FlagName = ""
+		}
+		pi.Deprecated = field.Tag.Get("deprecated")
+		for _, alias := range splitTagList(field.Tag.Get("alias")) {
+			pi.Aliases = append(pi.Aliases, newParamAlias(&pi, alias, naming, envPrefix, flagPrefix, filePrefix))
 		}
 
 		ci.params = append(ci.params, pi)
 		if field.Tag.Get("use_as_show_help_flag") != "" && field.Type.Kind() == reflect.Bool {
 			ci.helpFlagParamNumber = len(ci.params) // after append
 		}
-		if field.Tag.Get("use_as_example_printing_flag") != "" && field.Type.Kind() == reflect.Bool {
+		if field.Tag.Get("use_as_example_printing_flag") != "" && (field.Type.Kind() == reflect.Bool || field.Type.Kind() == reflect.String) {
 			ci.exampleFlagParamNumber = len(ci.params) // after append
 		}
+		if field.Tag.Get("use_as_example_format") != "" && field.Type.Kind() == reflect.String {
+			ci.exampleFormatParamNumber = len(ci.params) // after append
+		}
 		if field.Tag.Get("use_as_config_file_name") != "" && field.Type.Kind() == reflect.String {
 			ci.configNameParamNumber = len(ci.params) // after append
 		}
+		if field.Tag.Get("use_as_completion_flag") != "" && field.Type.Kind() == reflect.String {
+			ci.completionParamNumber = len(ci.params) // after append
+		}
+		if field.Tag.Get("use_as_env_file_name") != "" && field.Type.Kind() == reflect.String {
+			ci.envFileParamNumber = len(ci.params) // after append
+		}
 	}
 }
 
-type loadSource byte
+// Sections returns a copy of the nested structures list in order of the fields declaration
+func (ci *ConfigInfo) Sections() []SectionInfo {
+	return slices.Clone(ci.sections)
+}
 
-const (
-	LoadSourceDefaults loadSource = iota
-	LoadSourceFlags
-	LoadSourceEnvs
-)
+// Params returns a copy of the configuration parameters list
+func (ci *ConfigInfo) Params() ParamList {
+	result := make(ParamList, len(ci.params))
+	for idx := range ci.params {
+		result[idx] = ci.params[idx].clone()
+	}
+	return result
+}
 
-// LoadInOrder - loads field values from specified source order, can be used for init default config.
+// LoadInOrder - loads field values from specified sources one after another, can be used for init default config.
+// Built-in sources are LoadSourceDefaults, LoadSourceEnvFile, LoadSourceFlags, LoadSourceEnvs and LoadSourceFile,
+// custom sources implement ValueSource or DecodeSource.
 //   - config - a pointer to structure where the configuration is planned to be loaded
-func (ci *ConfigInfo) LoadInOrder(config any, order ...loadSource) error {
+func (ci *ConfigInfo) LoadInOrder(config any, order ...Source) error {
+	return ci.loadInOrder(context.Background(), config, order)
+}
+
+func (ci *ConfigInfo) loadInOrder(ctx context.Context, config any, order []Source) error {
 	rv := reflect.ValueOf(config)
 	if rv.Kind() != reflect.Ptr {
 		return errors.New("value is not a pointer to struct")
@@ -116,85 +245,390 @@ func (ci *ConfigInfo) LoadInOrder(config any, order ...loadSource) error {
 		return errors.New("value is not a pointer to struct")
 	}
 
-	var flags map[string]string
-	if slices.Contains(order, LoadSourceFlags) {
-		flags = parseFlags(os.Args[1:])
+	for idx := range ci.params {
+		ci.params[idx].Source = LoadSourceNone
+	}
+	ci.updateMagicValues(rv)
+
+	var values sourceValues
+	var err error
+	if slices.Contains(order, Source(LoadSourceFlags)) {
+		// without flags source the command, selected by SetCommand, is kept
+		if values.flags, values.args, err = ci.loadCommandLine(); err != nil {
+			return err
+		}
 	}
 
-	for idx, param := range ci.params {
-		field := rv.FieldByIndex(param.index)
+	if slices.Contains(order, Source(LoadSourceEnvFile)) && ci.envFileParamNumber > 0 {
+		// name of env-file has to be known before loading of other parameters
+		envFileIdx := ci.envFileParamNumber - 1
 		for _, source := range order {
-			switch source {
-			case LoadSourceDefaults:
-				if param.Default != "" {
-					if err := parseFieldValue(field, param.Default); err != nil {
-						return fmt.Errorf("can't parse default value `%s` for %s: %w", param.Default, param.Path, err)
-					}
-				}
-			case LoadSourceEnvs:
-				if param.EnvName != "" {
-					if envValue, exists := os.LookupEnv(param.EnvName); exists && envValue != "" {
-						if err := parseFieldValue(field, envValue); err != nil {
-							return fmt.Errorf("can't parse env value `%s` for %s: %w", envValue, param.Path, err)
-						}
-					}
-				}
-			case LoadSourceFlags:
-				if param.FlagName != "" {
-					if flagValue, exists := flags[param.FlagName]; exists {
-						if err := parseFieldValue(field, flagValue); err != nil {
-							return fmt.Errorf("can't parse flag value `%s` for %s: %w", flagValue, param.Path, err)
-						}
-					}
-				}
+			if source == LoadSourceEnvFile || source == LoadSourceFile {
+				continue
+			}
+			if _, ok := source.(DecodeSource); ok {
+				continue
+			}
+			if err := ci.loadParam(rv, envFileIdx, source, &values); err != nil {
+				return err
 			}
 		}
+		ci.updateMagicValues(rv)
+		if values.envFile, err = ci.readEnvFile(&ci.params[envFileIdx]); err != nil {
+			return err
+		}
+	}
 
-		if idx+1 == ci.helpFlagParamNumber {
-			ci.helpFlagParamValue = field.Bool()
+	// errors of parameters are collected from all sources, other errors stop loading
+	var fieldErrs []error
+	for _, source := range order {
+		if err := ctx.Err(); err != nil {
+			return errors.Join(append(fieldErrs, err)...)
 		}
-		if idx+1 == ci.exampleFlagParamNumber {
-			ci.exampleFlagParamValue = field.Bool()
+		errs, err := ci.loadSource(ctx, rv, source, &values)
+		fieldErrs = append(fieldErrs, errs...)
+		if err != nil {
+			return errors.Join(append(fieldErrs, err)...)
 		}
-		if idx+1 == ci.configNameParamNumber {
-			ci.configNameParamValue = field.String()
+	}
+	ci.warnDeprecated()
+
+	return errors.Join(fieldErrs...)
+}
+
+// sourceValues contains values of sources, prepared for loading
+type sourceValues struct {
+	flags   map[string]map[string]string // flags by names of commands, "" for global flags
+	args    []string                     // positional arguments of the selected command
+	envFile map[string]string
+}
+
+// loadSource loads values of all parameters from the source, returns errors of parameters (as *FieldError)
+// and error of the source itself
+func (ci *ConfigInfo) loadSource(ctx context.Context, rv reflect.Value, source Source, values *sourceValues) (fieldErrs []error, err error) {
+	if decoder, ok := source.(DecodeSource); ok {
+		var loaded []string
+		if ctxDecoder, ok := source.(contextDecodeSource); ok {
+			loaded, err = ctxDecoder.DecodeContext(ctx, rv.Addr().Interface(), ci.Params())
+		} else {
+			loaded, err = decoder.Decode(rv.Addr().Interface(), ci.Params())
+		}
+		if err != nil {
+			return nil, fmt.Errorf("can't load values from %s: %w", source, err)
 		}
+		for _, path := range loaded {
+			if param := ci.params.paramByPath(path); param != nil {
+				param.Source = source
+			}
+		}
+	} else if source == LoadSourceFile {
+		if err = ci.tryLoadConfigFile(ctx, rv.Addr().Interface()); err != nil {
+			return nil, err
+		}
+	} else if source == LoadSourceDefaults {
+		if fieldErrs, err = ci.loadDefaults(rv, values); err != nil {
+			return nil, err
+		}
+	} else if isBuiltinValueSource(source) || isValueSource(source) {
+		for idx := range ci.params {
+			if errParam := ci.loadParam(rv, idx, source, values); errParam != nil {
+				fieldErrs = append(fieldErrs, errParam)
+			}
+		}
+	} else {
+		return nil, fmt.Errorf("unsupported source %s", source)
+	}
+
+	ci.updateMagicValues(rv)
+	return fieldErrs, nil
+}
+
+func isBuiltinValueSource(source Source) bool {
+	switch source {
+	case LoadSourceDefaults, LoadSourceEnvFile, LoadSourceEnvs, LoadSourceFlags:
+		return true
+	default:
+		return false
+	}
+}
+
+func isValueSource(source Source) bool {
+	_, ok := source.(ValueSource)
+	return ok
+}
+
+// loadParam loads value of the parameter from the source, if the source contains it
+func (ci *ConfigInfo) loadParam(rv reflect.Value, idx int, source Source, values *sourceValues) error {
+	param := &ci.params[idx]
+	if !ci.isParamSelected(param) {
+		return nil // parameters of other commands are not loaded
+	}
+	field := rv.FieldByIndex(param.index)
+	if param.Arg == ArgRest && source == LoadSourceFlags {
+		return ci.loadRestArgs(param, field, values.args)
+	}
+	value, found, err := ci.lookupValue(param, source, values)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return nil
+	}
+	if value == "" && source == LoadSourceFlags && idx+1 == ci.exampleFlagParamNumber && field.Kind() == reflect.String {
+		value = ExampleFormatYAML // in case then flag is "--example"
+	}
+	if source == LoadSourceFlags && idx+1 == ci.exampleFlagParamNumber && field.Kind() == reflect.Bool &&
+		ci.exampleFormatParamNumber > 0 && isExampleFormat(value) {
+		// in case then flag is "--example=env", the format is stored to its own parameter
+		formatParam := &ci.params[ci.exampleFormatParamNumber-1]
+		rv.FieldByIndex(formatParam.index).SetString(value)
+		formatParam.Source = source
+		value = "true"
 	}
+	if err = ci.setRawValue(param, field, value); err != nil {
+		return newFieldError(fieldErrorParse, param, source, value, err)
+	}
+	param.Source = source
 
 	return nil
 }
 
+// lookupValue returns text value of the parameter from the source
+func (ci *ConfigInfo) lookupValue(param *ParamInfo, source Source, values *sourceValues) (string, bool, error) {
+	switch source {
+	case LoadSourceDefaults:
+		return param.Default, param.Default != "", nil
+	case LoadSourceEnvFile:
+		return ci.lookupAliased(param, source, param.EnvName, aliasEnvName, func(name string) (string, bool) {
+			value, found := values.envFile[name]
+			return value, found && value != ""
+		})
+	case LoadSourceEnvs:
+		return ci.lookupAliased(param, source, param.EnvName, aliasEnvName, func(name string) (string, bool) {
+			value, found := ci.lookupEnv(name)
+			return value, found && value != ""
+		})
+	case LoadSourceFlags:
+		if param.Arg != "" {
+			pos, _ := strconv.Atoi(param.Arg) // checked by checkArgParams
+			if pos < len(values.args) {
+				return values.args[pos], true, nil
+			}
+			return "", false, nil
+		}
+		return ci.lookupAliased(param, source, param.FlagName, aliasFlagName, func(name string) (string, bool) {
+			value, found := values.flags[param.Command][name]
+			return value, found
+		})
+	}
+
+	lookuper, ok := source.(ValueSource)
+	if !ok {
+		return "", false, fmt.Errorf("unsupported source %s", source)
+	}
+	p := param.clone()
+	value, found, err := lookuper.Lookup(&p)
+	if err != nil {
+		return "", false, newFieldError(fieldErrorLookup, param, source, "", err)
+	}
+	return value, found, nil
+}
+
+// updateMagicValues copies values of parameters, used by ConfigInfo itself, e.g. name of config file
+func (ci *ConfigInfo) updateMagicValues(rv reflect.Value) {
+	exampleFormat := ""
+	for idx := range ci.params {
+		field := rv.FieldByIndex(ci.params[idx].index)
+		switch idx + 1 {
+		case ci.helpFlagParamNumber:
+			ci.helpFlagParamValue = field.Bool()
+		case ci.exampleFlagParamNumber:
+			ci.exampleFormat = field.String()
+			if field.Kind() == reflect.Bool {
+				ci.exampleFormat = ""
+				if field.Bool() {
+					ci.exampleFormat = ExampleFormatYAML
+				}
+			}
+		case ci.exampleFormatParamNumber:
+			exampleFormat = field.String()
+		case ci.configNameParamNumber:
+			ci.configNameParamValue = field.String()
+		case ci.completionParamNumber:
+			ci.completionParamValue = field.String()
+		case ci.envFileParamNumber:
+			ci.envFileParamValue = field.String()
+		}
+	}
+	if ci.exampleFormat != "" && exampleFormat != "" {
+		ci.exampleFormat = exampleFormat
+	}
+}
+
+// readEnvFile reads variables from env-file, specified by the parameter.
+// Missing file is ignored, if its name is taken from default value.
+func (ci *ConfigInfo) readEnvFile(param *ParamInfo) (map[string]string, error) {
+	if ci.envFileParamValue == "" {
+		return nil, nil
+	}
+
+	data, err := ci.readFile(ci.envFileParamValue)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) && param.Source == LoadSourceDefaults {
+			return nil, nil
+		}
+		return nil, &FileError{File: ci.envFileParamValue, Err: err, op: "read env-file"}
+	}
+
+	result, err := parseDotEnv(string(data), ci.lookupEnv)
+	if err != nil {
+		var fileErr *FileError
+		if errors.As(err, &fileErr) {
+			fileErr.File = ci.envFileParamValue
+		}
+		return nil, err
+	}
+
+	return result, nil
+}
+
 // TryLoadConfigFile - loads field values from config-file, if specified in ConfigInfo
 //   - config - a pointer to structure where the configuration is planned to be loaded
 func (ci *ConfigInfo) TryLoadConfigFile(config any) error {
+	return ci.tryLoadConfigFile(context.Background(), config)
+}
+
+func (ci *ConfigInfo) tryLoadConfigFile(ctx context.Context, config any) error {
 	if ci.configNameParamValue == "" {
 		return nil
 	}
 
-	// Load config from file
-	// Читаем файл
-	data, err := os.ReadFile(ci.configNameParamValue)
-	if err != nil {
-		return fmt.Errorf("failed to read config file: %v", err)
+	var loaded []string
+	if isConfigURL(ci.configNameParamValue) {
+		// Load config from HTTP server
+		var err error
+		if loaded, err = ci.httpSourceFor(ci.configNameParamValue).DecodeContext(ctx, config, ci.params); err != nil {
+			return err
+		}
+	} else {
+		// Load config from file
+		// Читаем файл
+		data, err := ci.readFile(ci.configNameParamValue)
+		if err != nil {
+			return &FileError{File: ci.configNameParamValue, Err: err, op: "read config file"}
+		}
+		loaded, err = decodeConfigData(ci.configNameParamValue, data, config, ci.params, ci.strict, ci.log(), ci.interpolateNodes)
+		if err != nil {
+			return err
+		}
 	}
-	if err = yaml.Unmarshal(data, config); err != nil {
-		return fmt.Errorf("failed to unmarshal config file: %v", err)
+
+	for _, path := range loaded {
+		if param := ci.params.paramByPath(path); param != nil {
+			param.Source = LoadSourceFile
+		}
 	}
 
 	return nil
 }
 
+// decodeConfigData decodes YAML (or JSON) document into `config`, returns paths of parameters present in the document
+//   - name - name or URL of the document, used in errors
+//   - strict - unknown keys are errors
+//   - logger - logger for warnings about old keys of parameters
+//   - interpolate - expands variables in values of the document before decoding, returns true if anything is changed,
+//     can be nil
+func decodeConfigData(name string, data []byte, config any, params ParamList, strict bool, logger *slog.Logger,
+	interpolate func(root *yaml.Node) (bool, error),
+) ([]string, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, yamlFileError(name, err)
+	}
+	if root.Kind == 0 {
+		return nil, nil // empty file
+	}
+	changed, err := renameOldKeys(&root, name, params, logger)
+	if err != nil {
+		return nil, err
+	}
+	if interpolate != nil {
+		interpolated, err := interpolate(&root)
+		if err != nil {
+			return nil, err
+		}
+		changed = changed || interpolated
+	}
+	if changed && strict {
+		if data, err = yaml.Marshal(&root); err != nil {
+			return nil, yamlFileError(name, err)
+		}
+	}
+	if strict {
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(config); err != nil {
+			return nil, yamlFileError(name, err)
+		}
+	} else if err := root.Decode(config); err != nil {
+		return nil, yamlFileError(name, err)
+	}
+
+	var loaded []string
+	for idx := range params {
+		if params[idx].FileKey != "" && hasNodePath(&root, strings.Split(params[idx].FileKey, ".")) {
+			loaded = append(loaded, params[idx].Path)
+		}
+	}
+
+	return loaded, nil
+}
+
+// hasNodePath checks that yaml-document contains mapping keys sequence
+func hasNodePath(node *yaml.Node, keys []string) bool {
+	for node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	if len(keys) == 0 {
+		return true
+	}
+	if node.Kind != yaml.MappingNode {
+		return false
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == keys[0] {
+			return hasNodePath(node.Content[i+1], keys[1:])
+		}
+	}
+	return false
+}
+
 // DefaultLoadOrder - default param-source order for loading in Load method
-var DefaultLoadOrder = []loadSource{LoadSourceDefaults, LoadSourceFlags, LoadSourceEnvs}
+var DefaultLoadOrder = []Source{LoadSourceDefaults, LoadSourceEnvFile, LoadSourceFlags, LoadSourceEnvs}
 
-// Load - loads field values from defaults, then from environment, when from flags, when from config, if specified
+// Load - loads field values from defaults, then from env-file, when from flags, when from environment, when from config, if specified,
+// interpolates variables, if enabled, and validates the result, unless help, example or completion script is requested
 //   - config - a pointer to structure where the configuration is planned to be loaded
 func (ci *ConfigInfo) Load(config any) error {
-	if err := ci.LoadInOrder(config, DefaultLoadOrder...); err != nil {
+	return ci.load(context.Background(), config, append(slices.Clone(DefaultLoadOrder), LoadSourceFile), true)
+}
+
+// load loads field values from sources in order, interpolates variables, if enabled,
+// and validates the result, if `validate` is set, unless help, example or completion script is requested
+func (ci *ConfigInfo) load(ctx context.Context, config any, order []Source, validate bool) error {
+	if err := ci.loadInOrder(ctx, config, order); err != nil {
 		return err
 	}
 
-	return ci.TryLoadConfigFile(config)
+	if err := ci.Interpolate(config); err != nil {
+		return err
+	}
+
+	if !validate || ci.HasHelpFlag() || ci.HasExampleFlag() || ci.HasCompletionFlag() {
+		return nil
+	}
+
+	return ci.Validate(config)
 }
 
 // HasHelpFlag checks that the "help" flag is set
@@ -204,28 +638,11 @@ func (ci *ConfigInfo) HasHelpFlag() bool {
 
 // HasExampleFlag checks that the "example" flag is set
 func (ci *ConfigInfo) HasExampleFlag() bool {
-	return ci.exampleFlagParamValue
-}
-
-// ShowHelp showing help
-func (ci *ConfigInfo) ShowHelp() {
-	// Showing parameters help
-	const lineFormat = "%-30s %-30s %-15s %s\n"
-	fmt.Println("List or program parameters")
-	_, _ = fmt.Printf(lineFormat, "Environment param", "command-line flag", "default value", "description")
-	for _, param := range ci.params {
-		fmt.Printf(lineFormat, param.EnvName, param.FlagName, param.Default, param.HelpText)
-	}
+	return ci.exampleFormat != ""
 }
 
-// ShowExample showing config example based on `config` data
-func (ci *ConfigInfo) ShowExample(config any) error {
-	// printing config file example
-	data, err := yaml.Marshal(config)
-	if err != nil {
-		return fmt.Errorf("failed to marshal config file for printing: %v", err)
-	}
-	fmt.Printf("Config file example:\n## >>>>> config file starts here >>>>>\n%s## >>>>> config file ends here <<<<<<\n", string(data))
-
-	return nil
+// ExampleFormat returns the format of requested config example, one of ExampleFormatYAML, ExampleFormatJSON,
+// ExampleFormatEnv or ExampleFormatFlags, empty if example is not requested
+func (ci *ConfigInfo) ExampleFormat() string {
+	return ci.exampleFormat
 }
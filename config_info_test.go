@@ -1,8 +1,8 @@
 package appconfig
 
 import (
+	"io/fs"
 	"os"
-	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -43,8 +43,8 @@ func TestNewConfigInfo(t *testing.T) {
 			expectedCI: &ConfigInfo{
 				helpFlagParamNumber: 2,
 				params: ParamList{
-					{Path: "Param", EnvName: PFX + "_P", FlagName: "--f", HelpText: "h", Default: "d", index: []int{0}},
-					{Path: "Help", EnvName: PFX + "_E1", FlagName: "--f1", HelpText: "h1", Default: "d1", index: []int{1}},
+					{Path: "Param", EnvName: PFX + "_P", FlagName: "--f", FileKey: "param", HelpText: "h", Default: "d", index: []int{0}},
+					{Path: "Help", EnvName: PFX + "_E1", FlagName: "--f1", FileKey: "help", HelpText: "h1", Default: "d1", index: []int{1}},
 				},
 			},
 		},
@@ -58,8 +58,8 @@ func TestNewConfigInfo(t *testing.T) {
 			expectedCI: &ConfigInfo{
 				exampleFlagParamNumber: 2,
 				params: ParamList{
-					{Path: "Param", EnvName: PFX + "_P", FlagName: "--f", HelpText: "h", Default: "d", index: []int{0}},
-					{Path: "Example", EnvName: PFX + "_E1", FlagName: "--f1", HelpText: "h1", Default: "d1", index: []int{1}},
+					{Path: "Param", EnvName: PFX + "_P", FlagName: "--f", FileKey: "param", HelpText: "h", Default: "d", index: []int{0}},
+					{Path: "Example", EnvName: PFX + "_E1", FlagName: "--f1", FileKey: "example", HelpText: "h1", Default: "d1", index: []int{1}},
 				},
 			},
 		},
@@ -73,8 +73,8 @@ func TestNewConfigInfo(t *testing.T) {
 			expectedCI: &ConfigInfo{
 				configNameParamNumber: 2,
 				params: ParamList{
-					{Path: "Param", EnvName: PFX + "_P", FlagName: "--f", HelpText: "h", Default: "d", index: []int{0}},
-					{Path: "Config", EnvName: PFX + "_E1", FlagName: "--f1", HelpText: "h1", Default: "d1", index: []int{1}},
+					{Path: "Param", EnvName: PFX + "_P", FlagName: "--f", FileKey: "param", HelpText: "h", Default: "d", index: []int{0}},
+					{Path: "Config", EnvName: PFX + "_E1", FlagName: "--f1", FileKey: "config", HelpText: "h1", Default: "d1", index: []int{1}},
 				},
 			},
 		},
@@ -96,14 +96,19 @@ func TestNewConfigInfo(t *testing.T) {
 				helpFlagParamNumber:    1,
 				exampleFlagParamNumber: 2,
 				configNameParamNumber:  3,
+				sections: []SectionInfo{
+					{Path: "ForInclude", FileKey: "forinclude", Anonymous: true},
+					{Path: "Sub", FileKey: "sub"},
+					{Path: "Sub.Fld", FileKey: "sub.fld"},
+				},
 				params: ParamList{
-					{Path: "ForInclude.Help", EnvName: PFX + "_E1", FlagName: "--f1", HelpText: "h1", Default: "d1", index: []int{0, 0}},
-					{Path: "ForInclude.Example", EnvName: PFX + "_E1", FlagName: "--f1", HelpText: "h1", Default: "d1", index: []int{0, 1}},
-					{Path: "ForInclude.Config", EnvName: PFX + "_E1", FlagName: "--f1", HelpText: "h1", Default: "d1", index: []int{0, 2}},
-					{Path: "Sub.Fld.Param", EnvName: PFX + "_SE_FLD_P", FlagName: "--sf-fld-f", HelpText: "h", Default: "d", index: []int{1, 0, 0}},
-					{Path: "Sub.Bool", EnvName: PFX + "_SE_P1", FlagName: "--sf-f1", HelpText: "h1", Default: "d1", index: []int{1, 1}},
-					{Path: "Sub.Str", EnvName: PFX + "_SE_P2", FlagName: "--sf-f2", HelpText: "h2", Default: "d2", index: []int{1, 2}},
-					{Path: "Sub.Float", EnvName: PFX + "_SE_P3", FlagName: "--sf-f3", HelpText: "h3", Default: "d3", index: []int{1, 3}},
+					{Path: "ForInclude.Help", EnvName: PFX + "_E1", FlagName: "--f1", FileKey: "forinclude.help", HelpText: "h1", Default: "d1", index: []int{0, 0}},
+					{Path: "ForInclude.Example", EnvName: PFX + "_E1", FlagName: "--f1", FileKey: "forinclude.example", HelpText: "h1", Default: "d1", index: []int{0, 1}},
+					{Path: "ForInclude.Config", EnvName: PFX + "_E1", FlagName: "--f1", FileKey: "forinclude.config", HelpText: "h1", Default: "d1", index: []int{0, 2}},
+					{Path: "Sub.Fld.Param", EnvName: PFX + "_SE_FLD_P", FlagName: "--sf-fld-f", FileKey: "sub.fld.param", HelpText: "h", Default: "d", index: []int{1, 0, 0}},
+					{Path: "Sub.Bool", EnvName: PFX + "_SE_P1", FlagName: "--sf-f1", FileKey: "sub.bool", HelpText: "h1", Default: "d1", index: []int{1, 1}},
+					{Path: "Sub.Str", EnvName: PFX + "_SE_P2", FlagName: "--sf-f2", FileKey: "sub.str", HelpText: "h2", Default: "d2", index: []int{1, 2}},
+					{Path: "Sub.Float", EnvName: PFX + "_SE_P3", FlagName: "--sf-f3", FileKey: "sub.float", HelpText: "h3", Default: "d3", index: []int{1, 3}},
 				},
 			},
 		},
@@ -119,6 +124,15 @@ func TestNewConfigInfo(t *testing.T) {
 			}
 
 			require.NoError(t, err)
+			require.NotNil(t, ci.configType)
+			ci.configType = nil
+			for idx := range ci.params {
+				require.NotNil(t, ci.params[idx].Type)
+				require.Equal(t, Source(LoadSourceNone), ci.params[idx].Source)
+				ci.params[idx].Type = nil
+				ci.params[idx].Source = nil
+				ci.params[idx].Tag = ""
+			}
 			require.Equal(t, tt.expectedCI, ci)
 		})
 	}
@@ -138,14 +152,12 @@ func TestConfigInfo_Load(t *testing.T) {
 		Flag    bool
 		Include SubCfg
 	}
-	osArgsSrc := os.Args
-	defer func() { os.Args = osArgsSrc }()
-
-	m := sync.Mutex{}
+	env := map[string]string{"PATH": "/usr/bin"}
 
 	tests := []struct {
 		name        string
-		setup       func()
+		args        []string
+		fsys        fs.FS
 		sourceCfg   any
 		expectedCfg TestCfg
 		ci          *ConfigInfo
@@ -163,24 +175,19 @@ func TestConfigInfo_Load(t *testing.T) {
 			wantErr:   true,
 		},
 		{
-			name: "invalid cfg file data",
-			setup: func() {
-				os.Args = append(osArgsSrc, "--config=test_cfg.invalid")
-			},
+			name:    "invalid cfg file data",
+			args:    []string{"--config=test_cfg.invalid"},
 			wantErr: true,
 		},
 		{
-			name: "invalid cfg file name",
-			setup: func() {
-				os.Args = append(osArgsSrc, "--config=test_cfg.not_exist")
-			},
+			name:    "invalid cfg file name",
+			args:    []string{"--config=test_cfg.not_exist"},
 			wantErr: true,
 		},
 		{
 			name: "valid cfg file",
-			setup: func() {
-				os.Args = append(osArgsSrc, "--config=test_cfg.valid")
-			},
+			args: []string{"--config=test_cfg.valid"},
+			fsys: os.DirFS("."),
 			expectedCfg: TestCfg{
 				ConfigBase: ConfigBase{
 					ConfigFile: "test_cfg.valid",
@@ -197,43 +204,33 @@ func TestConfigInfo_Load(t *testing.T) {
 		},
 		{
 			name: "help flag + name",
-			setup: func() {
-				os.Args = append(osArgsSrc, "--help")
-			},
+			args: []string{"--help"},
 			expectedCfg: TestCfg{
 				ConfigBase: ConfigBase{
 					ShowHelp: true,
 				},
-				Name: os.Getenv("PATH"),
+				Name: env["PATH"],
 			},
 		},
 		{
 			name: "example flag",
-			setup: func() {
-				os.Args = append(osArgsSrc, "--example", "--value=99")
-			},
+			args: []string{"--example", "--value=99"},
 			expectedCfg: TestCfg{
 				ConfigBase: ConfigBase{
 					PrintExample: true,
 				},
-				Name:  os.Getenv("PATH"),
+				Name:  env["PATH"],
 				Value: 99,
 			},
 		},
 		{
 			name: "path to name",
-			setup: func() {
-				os.Args = osArgsSrc
-			},
 			expectedCfg: TestCfg{
-				Name: os.Getenv("PATH"),
+				Name: env["PATH"],
 			},
 		},
 		{
 			name: "bad default",
-			setup: func() {
-				os.Args = osArgsSrc
-			},
 			sourceCfg: &struct {
 				Value int `default:"string"`
 			}{},
@@ -241,14 +238,19 @@ func TestConfigInfo_Load(t *testing.T) {
 		},
 		{
 			name: "bad flag value",
-			setup: func() {
-				os.Args = append(osArgsSrc, "--value=string")
-			},
+			args: []string{"--value=string"},
 			sourceCfg: &struct {
 				Value int
 			}{},
 			wantErr: true,
 		},
+		{
+			name: "required value missing",
+			sourceCfg: &struct {
+				Value int `required:"true"`
+			}{},
+			wantErr: true,
+		},
 		{
 			name: "bad env value",
 			sourceCfg: &struct {
@@ -260,8 +262,6 @@ func TestConfigInfo_Load(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			m.Lock()
-			defer m.Unlock()
 			cfg := tt.sourceCfg
 			if tt.sourceCfg == nil {
 				cfg = &TestCfg{}
@@ -270,11 +270,17 @@ func TestConfigInfo_Load(t *testing.T) {
 			ci, err := NewConfigInfo(cfg, "")
 			if tt.ci == nil {
 				require.NoError(t, err)
+			} else {
+				ci = tt.ci
 			}
 
-			if tt.setup != nil {
-				tt.setup()
+			args := tt.args
+			if args == nil {
+				args = []string{} // nil means arguments of the test binary
 			}
+			ci.SetArgs(args)
+			ci.SetEnv(env)
+			ci.SetFS(tt.fsys)
 
 			err = ci.Load(cfg)
 			if tt.wantErr {
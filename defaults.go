@@ -0,0 +1,75 @@
+package appconfig
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Defaulter can be implemented by the configuration structure or any nested structure to set computed defaults,
+// e.g. runtime.NumCPU(). SetDefaults is called after values from `default` tags are set, before other sources,
+// nested structures go before their parents. SetDefaults of embedded structure is promoted to its parent
+// and is called once, as the parent's one.
+type Defaulter interface {
+	SetDefaults()
+}
+
+// loadDefaults sets values from `default` tags of sections and parameters, then calls SetDefaults of Defaulter structures.
+// Parameters, changed by defaults of sections or by SetDefaults, get LoadSourceDefaults source too.
+func (ci *ConfigInfo) loadDefaults(rv reflect.Value, values *sourceValues) (fieldErrs []error, err error) {
+	initial := make([]any, len(ci.params))
+	for idx := range ci.params {
+		initial[idx] = rv.FieldByIndex(ci.params[idx].index).Interface()
+	}
+
+	for idx := range ci.sections {
+		section := &ci.sections[idx]
+		if section.Default == "" || !ci.isCommandSelected(section.Command) {
+			continue
+		}
+		if err = yaml.Unmarshal([]byte(section.Default), sectionField(rv, section.Path).Addr().Interface()); err != nil {
+			return nil, fmt.Errorf("invalid default value of section %s: %w", section.Path, err)
+		}
+	}
+
+	for idx := range ci.params {
+		if errParam := ci.loadParam(rv, idx, LoadSourceDefaults, values); errParam != nil {
+			fieldErrs = append(fieldErrs, errParam)
+		}
+	}
+
+	// nested sections are declared after their parents
+	for idx := len(ci.sections) - 1; idx >= 0; idx-- {
+		section := &ci.sections[idx]
+		if !section.Anonymous && ci.isCommandSelected(section.Command) {
+			callDefaulter(sectionField(rv, section.Path))
+		}
+	}
+	callDefaulter(rv)
+
+	for idx := range ci.params {
+		param := &ci.params[idx]
+		if param.Source == LoadSourceNone &&
+			!reflect.DeepEqual(initial[idx], rv.FieldByIndex(param.index).Interface()) {
+			param.Source = LoadSourceDefaults
+		}
+	}
+
+	return fieldErrs, nil
+}
+
+// sectionField returns the field of nested structure by its path
+func sectionField(rv reflect.Value, path string) reflect.Value {
+	for _, name := range strings.Split(path, ".") {
+		rv = rv.FieldByName(name)
+	}
+	return rv
+}
+
+func callDefaulter(rv reflect.Value) {
+	if defaulter, ok := rv.Addr().Interface().(Defaulter); ok {
+		defaulter.SetDefaults()
+	}
+}
@@ -0,0 +1,123 @@
+package appconfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type defaultsTestPool struct {
+	Size    int
+	MaxIdle int
+}
+
+func (p *defaultsTestPool) SetDefaults() {
+	p.Size = 4
+}
+
+type defaultsTestCfg struct {
+	Hosts  []string       `default:"[a, b]"`
+	Ports  []int          `default:"[80,443]"`
+	Limits map[string]int `default:"{\"cpu\": 2, \"mem\": 512}"`
+	TLS    struct {
+		Cert string
+		Key  string `default:"key.pem"`
+	} `default:"{cert: a.pem, key: b.pem}"`
+	Pool    defaultsTestPool
+	Workers int
+}
+
+func (c *defaultsTestCfg) SetDefaults() {
+	c.Workers = c.Pool.Size * 2 // nested defaults are already set
+}
+
+func TestConfigInfo_LoadDefaults(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected func(cfg *defaultsTestCfg)
+	}{
+		{
+			name: "defaults",
+			expected: func(cfg *defaultsTestCfg) {
+				cfg.Hosts = []string{"a", "b"}
+				cfg.Ports = []int{80, 443}
+				cfg.Limits = map[string]int{"cpu": 2, "mem": 512}
+				cfg.TLS.Cert = "a.pem"
+				cfg.TLS.Key = "key.pem"
+				cfg.Pool.Size = 4
+				cfg.Workers = 8
+			},
+		},
+		{
+			name: "environment overrides computed and composite defaults",
+			env:  map[string]string{"POOL_SIZE": "1", "HOSTS": `["x"]`, "LIMITS": "{cpu: 1}"},
+			expected: func(cfg *defaultsTestCfg) {
+				cfg.Hosts = []string{"x"}
+				cfg.Ports = []int{80, 443}
+				cfg.Limits = map[string]int{"cpu": 1}
+				cfg.TLS.Cert = "a.pem"
+				cfg.TLS.Key = "key.pem"
+				cfg.Pool.Size = 1
+				cfg.Workers = 8
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := &defaultsTestCfg{}
+			ci, err := NewConfigInfo(cfg, "")
+			require.NoError(t, err)
+			ci.SetArgs([]string{})
+			ci.SetEnv(tt.env)
+
+			require.NoError(t, ci.Load(cfg))
+			expected := &defaultsTestCfg{}
+			tt.expected(expected)
+			assert.Equal(t, expected, cfg)
+		})
+	}
+}
+
+func TestConfigInfo_LoadDefaults_Sources(t *testing.T) {
+	t.Parallel()
+	cfg := &defaultsTestCfg{}
+	ci, err := NewConfigInfo(cfg, "")
+	require.NoError(t, err)
+	require.NoError(t, ci.LoadInOrder(cfg, LoadSourceDefaults))
+
+	params := ci.Params()
+	for _, path := range []string{"Hosts", "TLS.Cert", "Pool.Size", "Workers"} {
+		param, _ := params.ByPath(path)
+		assert.Equal(t, Source(LoadSourceDefaults), param.Source, path)
+	}
+	param, _ := params.ByPath("Pool.MaxIdle")
+	assert.Equal(t, Source(LoadSourceNone), param.Source)
+
+	// Defaulter is not called without defaults source
+	cfg = &defaultsTestCfg{}
+	require.NoError(t, ci.LoadInOrder(cfg, LoadSourceEnvs))
+	assert.Zero(t, cfg.Pool.Size)
+}
+
+func TestConfigInfo_LoadDefaults_Errors(t *testing.T) {
+	t.Parallel()
+	cfg := &struct {
+		Hosts []int `default:"[a"`
+	}{}
+	ci, err := NewConfigInfo(cfg, "")
+	require.NoError(t, err)
+	require.ErrorContains(t, ci.LoadInOrder(cfg, LoadSourceDefaults), "can't parse default value `[a` for Hosts")
+
+	section := &struct {
+		TLS struct {
+			Port int
+		} `default:"{port: http}"`
+	}{}
+	ci, err = NewConfigInfo(section, "")
+	require.NoError(t, err)
+	require.ErrorContains(t, ci.LoadInOrder(section, LoadSourceDefaults), "invalid default value of section TLS")
+}
@@ -0,0 +1,175 @@
+package appconfig
+
+import (
+	"fmt"
+	"log/slog"
+	"reflect"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SetLogger sets logger for warnings about usage of deprecated parameters and their old names, instead of slog.Default()
+func (ci *ConfigInfo) SetLogger(logger *slog.Logger) {
+	ci.logger = logger
+	ci.httpSource = nil
+}
+
+func (ci *ConfigInfo) log() *slog.Logger {
+	if ci.logger != nil {
+		return ci.logger
+	}
+	return slog.Default()
+}
+
+// newParamAlias builds old names of the parameter from the alias, the same way as names of the parameter
+func newParamAlias(param *ParamInfo, alias string, naming NamingStrategy, envPrefix, flagPrefix, filePrefix string) ParamAlias {
+	var result ParamAlias
+	if param.EnvName != "" {
+		result.EnvName = addPrefix(naming.EnvName(strings.ReplaceAll(alias, FlagSeparator, EnvSeparator)), envPrefix, EnvSeparator)
+	}
+	if param.FlagName != "" {
+		result.FlagName = addPrefix(naming.FlagName(strings.ReplaceAll(alias, EnvSeparator, FlagSeparator)), flagPrefix, FlagSeparator)
+	}
+	if param.FileKey != "" {
+		result.FileKey = addPrefix(strings.ToLower(alias), filePrefix, ".")
+	}
+	return result
+}
+
+// deprecationText returns the message of deprecation from `deprecated` tag, or advice to use the current name
+func (p *ParamInfo) deprecationText() string {
+	if p.Deprecated != "" {
+		return p.Deprecated
+	}
+	for _, name := range []string{p.FlagName, p.EnvName, p.FileKey} {
+		if name != "" {
+			return "use " + name
+		}
+	}
+	return ""
+}
+
+// helpParams returns the parameter for help, followed by rows of its old names, marked as deprecated
+func (p *ParamInfo) helpParams() []ParamInfo {
+	param := p.clone()
+	if p.Deprecated != "" && len(p.Aliases) == 0 {
+		param.HelpText = strings.TrimSpace(param.HelpText + " (deprecated: " + p.Deprecated + ")")
+	}
+	result := []ParamInfo{param}
+	for _, alias := range p.Aliases {
+		if alias.EnvName == "" && alias.FlagName == "" {
+			continue
+		}
+		old := p.clone()
+		old.EnvName, old.FlagName, old.FileKey = alias.EnvName, alias.FlagName, alias.FileKey
+		old.Default = ""
+		old.HelpText = "deprecated, " + p.deprecationText()
+		old.Aliases = nil
+		result = append(result, old)
+	}
+	return result
+}
+
+// lookupAliased looks up the value by the name of the parameter and by its old names.
+// Usage of an old name is warned, different values of the name and an old name are an error.
+//   - aliasName - returns old name of the same kind as `name`
+//   - lookup - returns value by name
+func (ci *ConfigInfo) lookupAliased(param *ParamInfo, source Source, name string, aliasName func(alias *ParamAlias) string,
+	lookup func(name string) (string, bool),
+) (string, bool, error) {
+	if name == "" {
+		return "", false, nil
+	}
+	value, found := lookup(name)
+	for idx := range param.Aliases {
+		oldName := aliasName(&param.Aliases[idx])
+		if oldName == "" {
+			continue
+		}
+		oldValue, oldFound := lookup(oldName)
+		if !oldFound {
+			continue
+		}
+		ci.warnOldName(param, oldName, name)
+		if found && oldValue != value {
+			return "", false, newFieldError(fieldErrorLookup, param, source, "",
+				fmt.Errorf("conflicting values of %s and deprecated %s", name, oldName))
+		}
+		value, found = oldValue, true
+	}
+	return value, found, nil
+}
+
+func aliasEnvName(alias *ParamAlias) string { return alias.EnvName }
+
+func aliasFlagName(alias *ParamAlias) string { return alias.FlagName }
+
+func (ci *ConfigInfo) warnOldName(param *ParamInfo, oldName, name string) {
+	warnOldName(ci.log(), param, oldName, name)
+}
+
+func warnOldName(logger *slog.Logger, param *ParamInfo, oldName, name string) {
+	logger.Warn("deprecated name of configuration parameter is used",
+		"name", oldName, "use", name, "param", param.Path, "message", param.deprecationText())
+}
+
+// warnDeprecated warns about deprecated parameters without aliases, which are loaded from sources other than defaults
+func (ci *ConfigInfo) warnDeprecated() {
+	for idx := range ci.params {
+		param := &ci.params[idx]
+		if param.Deprecated == "" || len(param.Aliases) > 0 ||
+			param.Source == LoadSourceNone || param.Source == LoadSourceDefaults {
+			continue
+		}
+		ci.log().Warn("deprecated configuration parameter is used",
+			"param", param.Path, "source", param.Source.String(), "message", param.Deprecated)
+	}
+}
+
+// renameOldKeys replaces old keys of parameters in YAML document with current ones, returns true if anything is replaced.
+// Usage of an old key is warned, different values of the key and an old key are an error.
+func renameOldKeys(root *yaml.Node, name string, params ParamList, logger *slog.Logger) (bool, error) {
+	var renamed bool
+	for idx := range params {
+		param := &params[idx]
+		keys := strings.Split(param.FileKey, ".")
+		for _, alias := range param.Aliases {
+			if alias.FileKey == "" {
+				continue
+			}
+			mapping, pos := findMappingEntry(root, strings.Split(alias.FileKey, "."))
+			if mapping == nil {
+				continue
+			}
+			warnOldName(logger, param, alias.FileKey, param.FileKey)
+			renamed = true
+
+			current, currentPos := findMappingEntry(root, keys)
+			if current == nil {
+				mapping.Content[pos].Value = keys[len(keys)-1]
+				continue
+			}
+			if !equalNodes(mapping.Content[pos+1], current.Content[currentPos+1]) {
+				return false, &FileError{
+					File: name,
+					Line: mapping.Content[pos].Line,
+					Err:  fmt.Errorf("conflicting values of %s and deprecated %s", param.FileKey, alias.FileKey),
+					op:   "unmarshal config file",
+				}
+			}
+			mapping.Content = slices.Delete(mapping.Content, pos, pos+2)
+		}
+	}
+	return renamed, nil
+}
+
+// equalNodes checks that YAML nodes contain equal values
+func equalNodes(a, b *yaml.Node) bool {
+	var valueA, valueB any
+	if a.Decode(&valueA) != nil || b.Decode(&valueB) != nil {
+		return false
+	}
+	return reflect.DeepEqual(valueA, valueB)
+}
@@ -0,0 +1,159 @@
+package appconfig
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type deprecatedTestCfg struct {
+	ConfigBase
+	HTTP struct {
+		Address string `alias:"addr" deprecated:"use --http-address" help:"address to listen"`
+	}
+	Workers int    `deprecated:"workers are set automatically"`
+	Name    string `alias:"title old-name"`
+}
+
+func TestConfigInfo_Aliases(t *testing.T) {
+	t.Parallel()
+	ci, err := NewConfigInfo(&deprecatedTestCfg{}, "APP")
+	require.NoError(t, err)
+
+	params := ci.Params()
+	param, _ := params.ByPath("HTTP.Address")
+	assert.Equal(t, []ParamAlias{{EnvName: "APP_HTTP_ADDR", FlagName: "--http-addr", FileKey: "http.addr"}}, param.Aliases)
+	assert.Equal(t, "use --http-address", param.Deprecated)
+	param, _ = params.ByPath("Name")
+	assert.Equal(t, []ParamAlias{
+		{EnvName: "APP_TITLE", FlagName: "--title", FileKey: "title"},
+		{EnvName: "APP_OLD_NAME", FlagName: "--old-name", FileKey: "old-name"},
+	}, param.Aliases)
+}
+
+func TestConfigInfo_LoadAliases(t *testing.T) {
+	t.Parallel()
+	fsys := fstest.MapFS{
+		"old.yaml":      {Data: []byte("http:\n  addr: ':90'\ntitle: file\n")},
+		"same.yaml":     {Data: []byte("http:\n  address: ':90'\n  addr: ':90'\n")},
+		"conflict.yaml": {Data: []byte("http:\n  address: ':90'\n  addr: ':91'\n")},
+	}
+	tests := []struct {
+		name     string
+		args     []string
+		env      map[string]string
+		strict   bool
+		address  string
+		title    string
+		warnings []string
+		wantErr  string
+	}{
+		{
+			name: "current names",
+			args: []string{"--http-address=:80", "--name=app"},
+			env:  map[string]string{"APP_NAME": "app"},
+			// no warnings
+			address: ":80",
+			title:   "app",
+		},
+		{
+			name:     "old env name",
+			env:      map[string]string{"APP_HTTP_ADDR": ":80"},
+			address:  ":80",
+			warnings: []string{"name=APP_HTTP_ADDR use=APP_HTTP_ADDRESS param=HTTP.Address message=\"use --http-address\""},
+		},
+		{
+			name:     "old flag name",
+			args:     []string{"--old-name=app"},
+			title:    "app",
+			warnings: []string{"name=--old-name use=--name param=Name message=\"use --name\""},
+		},
+		{
+			name:     "old keys in config file",
+			args:     []string{"--config=old.yaml"},
+			strict:   true,
+			address:  ":90",
+			title:    "file",
+			warnings: []string{"name=http.addr use=http.address", "name=title use=name"},
+		},
+		{
+			name:     "equal values",
+			args:     []string{"--config=same.yaml"},
+			env:      map[string]string{"APP_HTTP_ADDR": ":90", "APP_HTTP_ADDRESS": ":90"},
+			address:  ":90",
+			warnings: []string{"name=APP_HTTP_ADDR", "name=http.addr"},
+		},
+		{
+			name:    "conflicting env",
+			env:     map[string]string{"APP_HTTP_ADDR": ":80", "APP_HTTP_ADDRESS": ":81"},
+			wantErr: "can't get value of HTTP.Address from env: conflicting values of APP_HTTP_ADDRESS and deprecated APP_HTTP_ADDR",
+		},
+		{
+			name:    "conflicting keys in config file",
+			args:    []string{"--config=conflict.yaml"},
+			wantErr: "failed to unmarshal config file conflict.yaml:3: conflicting values of http.address and deprecated http.addr",
+		},
+		{
+			name:     "deprecated parameter",
+			args:     []string{"--workers=3"},
+			warnings: []string{"msg=\"deprecated configuration parameter is used\" param=Workers source=flag message=\"workers are set automatically\""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			log := bytes.Buffer{}
+			cfg := &deprecatedTestCfg{}
+			ci, err := NewConfigInfo(cfg, "APP")
+			require.NoError(t, err)
+			ci.SetArgs(tt.args)
+			ci.SetEnv(tt.env)
+			ci.SetFS(fsys)
+			ci.SetStrict(tt.strict)
+			ci.SetLogger(slog.New(slog.NewTextHandler(&log, nil)))
+
+			err = ci.Load(cfg)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.address, cfg.HTTP.Address)
+			assert.Equal(t, tt.title, cfg.Name)
+			if len(tt.warnings) == 0 {
+				assert.Empty(t, log.String())
+			}
+			for _, warning := range tt.warnings {
+				assert.Contains(t, log.String(), warning)
+			}
+		})
+	}
+}
+
+func TestConfigInfo_DeprecatedHelp(t *testing.T) {
+	t.Parallel()
+	ci, err := NewConfigInfo(&deprecatedTestCfg{}, "APP")
+	require.NoError(t, err)
+
+	sb := strings.Builder{}
+	require.NoError(t, ci.WriteHelp(&sb, HelpOptions{Width: 120}))
+	assert.Regexp(t, `APP_HTTP_ADDRESS\s+--http-address\s+address to listen\n\s*APP_HTTP_ADDR\s+--http-addr\s+deprecated, use --http-address\n`, sb.String())
+	assert.Regexp(t, `APP_WORKERS\s+--workers\s+Workers \(deprecated: workers are set automatically\)\n`, sb.String())
+	assert.Regexp(t, `APP_OLD_NAME\s+--old-name\s+deprecated, use --name\n`, sb.String())
+}
+
+func TestLoader_WithLogger(t *testing.T) {
+	t.Parallel()
+	log := bytes.Buffer{}
+	cfg := &deprecatedTestCfg{}
+	loader := NewLoader(WithArgs([]string{"--http-addr=:80"}), WithEnv(nil), WithLogger(slog.New(slog.NewTextHandler(&log, nil))))
+	require.NoError(t, loader.Load(context.Background(), cfg))
+	assert.Equal(t, ":80", cfg.HTTP.Address)
+	assert.Contains(t, log.String(), "level=WARN msg=\"deprecated name of configuration parameter is used\" name=--http-addr")
+}
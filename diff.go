@@ -0,0 +1,189 @@
+package appconfig
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// DiffKind is a kind of difference between two configurations
+type DiffKind byte
+
+const (
+	DiffAdded DiffKind = iota + 1
+	DiffRemoved
+	DiffChanged
+)
+
+func (k DiffKind) String() string {
+	switch k {
+	case DiffAdded:
+		return "added"
+	case DiffRemoved:
+		return "removed"
+	case DiffChanged:
+		return "changed"
+	default:
+		return "unknown"
+	}
+}
+
+// DiffEntry describes difference of one parameter, map entry or slice element
+type DiffEntry struct {
+	Path   string   // parameter path, with key or index for map entries and slice elements, e.g. `Hosts[1]`
+	Kind   DiffKind // kind of difference
+	Old    any      // old value, nil for added entry; SecretPlaceholder for secret parameter
+	New    any      // new value, nil for removed entry; SecretPlaceholder for secret parameter
+	Secret bool     // values are masked, because parameter is secret
+}
+
+// String renders entry as `+ path: value`, `- path: value` or `~ path: old -> new`
+func (e DiffEntry) String() string {
+	switch e.Kind {
+	case DiffAdded:
+		return fmt.Sprintf("+ %s: %s", e.Path, formatDiffValue(e.New))
+	case DiffRemoved:
+		return fmt.Sprintf("- %s: %s", e.Path, formatDiffValue(e.Old))
+	default:
+		return fmt.Sprintf("~ %s: %s -> %s", e.Path, formatDiffValue(e.Old), formatDiffValue(e.New))
+	}
+}
+
+// DiffList is a list of differences, ordered as parameters
+type DiffList []DiffEntry
+
+// String renders differences, one entry per line
+func (dl DiffList) String() string {
+	sb := strings.Builder{}
+	for idx := range dl {
+		sb.WriteString(dl[idx].String())
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// Diff compares two configurations of the same type
+//   - oldCfg, newCfg - structures or pointers to them, where the configuration is loaded
+func Diff(oldCfg, newCfg any) (DiffList, error) {
+	ci, err := NewConfigInfo(oldCfg, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return ci.Diff(oldCfg, newCfg)
+}
+
+// Diff compares two configurations of the type, ConfigInfo is created for, by parameter paths.
+// Maps and slices are compared by keys and indexes, values of secret parameters are masked.
+func (ci *ConfigInfo) Diff(oldCfg, newCfg any) (DiffList, error) {
+	oldValue := reflect.Indirect(reflect.ValueOf(oldCfg))
+	newValue := reflect.Indirect(reflect.ValueOf(newCfg))
+	if !oldValue.IsValid() || !newValue.IsValid() {
+		return nil, fmt.Errorf("can't compare nil configurations")
+	}
+	if oldValue.Type() != newValue.Type() {
+		return nil, fmt.Errorf("can't compare configurations of different types %s and %s", oldValue.Type(), newValue.Type())
+	}
+	if ci.configType != nil && oldValue.Type() != ci.configType {
+		return nil, fmt.Errorf("can't compare configurations of type %s, expected %s", oldValue.Type(), ci.configType)
+	}
+
+	var result DiffList
+	for idx := range ci.params {
+		param := &ci.params[idx]
+		entries := diffValues(param.Path, oldValue.FieldByIndex(param.index), newValue.FieldByIndex(param.index))
+		if param.Secret {
+			for pos := range entries {
+				entries[pos].Secret = true
+				if entries[pos].Kind != DiffAdded {
+					entries[pos].Old = SecretPlaceholder
+				}
+				if entries[pos].Kind != DiffRemoved {
+					entries[pos].New = SecretPlaceholder
+				}
+			}
+		}
+		result = append(result, entries...)
+	}
+	return result, nil
+}
+
+func diffValues(path string, oldValue, newValue reflect.Value) []DiffEntry {
+	if reflect.DeepEqual(oldValue.Interface(), newValue.Interface()) {
+		return nil
+	}
+
+	switch oldValue.Kind() {
+	case reflect.Ptr:
+		switch {
+		case oldValue.IsNil():
+			return []DiffEntry{{Path: path, Kind: DiffAdded, New: newValue.Elem().Interface()}}
+		case newValue.IsNil():
+			return []DiffEntry{{Path: path, Kind: DiffRemoved, Old: oldValue.Elem().Interface()}}
+		default:
+			return diffValues(path, oldValue.Elem(), newValue.Elem())
+		}
+	case reflect.Map:
+		return diffMaps(path, oldValue, newValue)
+	case reflect.Slice, reflect.Array:
+		return diffSlices(path, oldValue, newValue)
+	default:
+		return []DiffEntry{{Path: path, Kind: DiffChanged, Old: oldValue.Interface(), New: newValue.Interface()}}
+	}
+}
+
+func diffMaps(path string, oldValue, newValue reflect.Value) []DiffEntry {
+	keys := make(map[string]reflect.Value)
+	for _, key := range oldValue.MapKeys() {
+		keys[fmt.Sprint(key.Interface())] = key
+	}
+	for _, key := range newValue.MapKeys() {
+		keys[fmt.Sprint(key.Interface())] = key
+	}
+	names := make([]string, 0, len(keys))
+	for name := range keys {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var result []DiffEntry
+	for _, name := range names {
+		entryPath := path + "[" + name + "]"
+		oldEntry := oldValue.MapIndex(keys[name])
+		newEntry := newValue.MapIndex(keys[name])
+		switch {
+		case !oldEntry.IsValid():
+			result = append(result, DiffEntry{Path: entryPath, Kind: DiffAdded, New: newEntry.Interface()})
+		case !newEntry.IsValid():
+			result = append(result, DiffEntry{Path: entryPath, Kind: DiffRemoved, Old: oldEntry.Interface()})
+		default:
+			result = append(result, diffValues(entryPath, oldEntry, newEntry)...)
+		}
+	}
+	return result
+}
+
+func diffSlices(path string, oldValue, newValue reflect.Value) []DiffEntry {
+	var result []DiffEntry
+	for idx := 0; idx < max(oldValue.Len(), newValue.Len()); idx++ {
+		entryPath := path + "[" + strconv.Itoa(idx) + "]"
+		switch {
+		case idx >= oldValue.Len():
+			result = append(result, DiffEntry{Path: entryPath, Kind: DiffAdded, New: newValue.Index(idx).Interface()})
+		case idx >= newValue.Len():
+			result = append(result, DiffEntry{Path: entryPath, Kind: DiffRemoved, Old: oldValue.Index(idx).Interface()})
+		default:
+			result = append(result, diffValues(entryPath, oldValue.Index(idx), newValue.Index(idx))...)
+		}
+	}
+	return result
+}
+
+func formatDiffValue(value any) string {
+	if text, ok := value.(string); ok && text != SecretPlaceholder {
+		return strconv.Quote(text)
+	}
+	return fmt.Sprint(value)
+}
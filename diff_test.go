@@ -0,0 +1,98 @@
+package appconfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+	type dbCfg struct {
+		Host     string
+		Password string `secret:"true"`
+	}
+	type testCfg struct {
+		Name   string
+		Port   int
+		Limit  *int
+		Hosts  []string
+		Labels map[string]string
+		DB     dbCfg
+	}
+	limit := 10
+	oldCfg := testCfg{
+		Name:   "app",
+		Port:   80,
+		Hosts:  []string{"a", "b", "c"},
+		Labels: map[string]string{"env": "dev", "team": "core"},
+		DB:     dbCfg{Host: "db", Password: "old"},
+	}
+	newCfg := testCfg{
+		Name:   "app",
+		Port:   81,
+		Limit:  &limit,
+		Hosts:  []string{"a", "x"},
+		Labels: map[string]string{"env": "prod", "zone": "eu"},
+		DB:     dbCfg{Host: "db", Password: "new"},
+	}
+
+	diff, err := Diff(&oldCfg, &newCfg)
+	require.NoError(t, err)
+	assert.Equal(t, DiffList{
+		{Path: "Port", Kind: DiffChanged, Old: 80, New: 81},
+		{Path: "Limit", Kind: DiffAdded, New: 10},
+		{Path: "Hosts[1]", Kind: DiffChanged, Old: "b", New: "x"},
+		{Path: "Hosts[2]", Kind: DiffRemoved, Old: "c"},
+		{Path: "Labels[env]", Kind: DiffChanged, Old: "dev", New: "prod"},
+		{Path: "Labels[team]", Kind: DiffRemoved, Old: "core"},
+		{Path: "Labels[zone]", Kind: DiffAdded, New: "eu"},
+		{Path: "DB.Password", Kind: DiffChanged, Old: SecretPlaceholder, New: SecretPlaceholder, Secret: true},
+	}, diff)
+	assert.Equal(t, `~ Port: 80 -> 81
++ Limit: 10
+~ Hosts[1]: "b" -> "x"
+- Hosts[2]: "c"
+~ Labels[env]: "dev" -> "prod"
+- Labels[team]: "core"
++ Labels[zone]: "eu"
+~ DB.Password: <secret> -> <secret>
+`, diff.String())
+
+	diff, err = Diff(newCfg, newCfg)
+	require.NoError(t, err)
+	assert.Empty(t, diff)
+
+	diff, err = Diff(newCfg, oldCfg)
+	require.NoError(t, err)
+	assert.Equal(t, DiffEntry{Path: "Limit", Kind: DiffRemoved, Old: 10}, diff[1])
+}
+
+func TestDiffErrors(t *testing.T) {
+	t.Parallel()
+	type cfgA struct{ Value int }
+	type cfgB struct{ Value int }
+
+	_, err := Diff(cfgA{}, cfgB{})
+	require.ErrorContains(t, err, "can't compare configurations of different types")
+
+	_, err = Diff(&cfgA{}, (*cfgA)(nil))
+	require.EqualError(t, err, "can't compare nil configurations")
+
+	_, err = Diff(1, 2)
+	require.Error(t, err)
+
+	ci, err := NewConfigInfo(cfgA{}, "")
+	require.NoError(t, err)
+	_, err = ci.Diff(&cfgB{}, &cfgB{})
+	require.ErrorContains(t, err, "can't compare configurations of type appconfig.cfgB, expected appconfig.cfgA")
+}
+
+func TestDiffKind_String(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "added", DiffAdded.String())
+	assert.Equal(t, "removed", DiffRemoved.String())
+	assert.Equal(t, "changed", DiffChanged.String())
+	assert.Equal(t, "unknown", DiffKind(0).String())
+}
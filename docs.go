@@ -0,0 +1,159 @@
+package appconfig
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+)
+
+// paramGroup contains parameters of a single nested section, the section is nil for the root of configuration
+type paramGroup struct {
+	section *SectionInfo
+	params  []*ParamInfo
+}
+
+// groupBySection groups parameters by nested sections in order of declaration,
+// parameters of embedded structures belong to the parent section
+func (ci *ConfigInfo) groupBySection() []paramGroup {
+	groups := []paramGroup{{}}
+	groupIdx := map[string]int{"": 0}
+	for idx := range ci.sections {
+		if !ci.sections[idx].Anonymous {
+			groupIdx[ci.sections[idx].Path] = len(groups)
+			groups = append(groups, paramGroup{section: &ci.sections[idx]})
+		}
+	}
+
+	for idx := range ci.params {
+		path := ci.params[idx].Path
+		for {
+			pos := strings.LastIndex(path, ".")
+			if pos < 0 {
+				path = ""
+				break
+			}
+			path = path[:pos]
+			if _, ok := groupIdx[path]; ok {
+				break
+			}
+		}
+		group := &groups[groupIdx[path]]
+		group.params = append(group.params, &ci.params[idx])
+	}
+
+	result := groups[:0]
+	for _, group := range groups {
+		if len(group.params) > 0 {
+			result = append(result, group)
+		}
+	}
+	return result
+}
+
+// constraints returns human-readable list of the parameter constraints
+func (p *ParamInfo) constraints() []string {
+	var result []string
+	if p.Required {
+		result = append(result, "required")
+	}
+	if len(p.Enum) > 0 {
+		result = append(result, "one of: "+strings.Join(p.Enum, ", "))
+	}
+	if p.Min != "" {
+		result = append(result, "min: "+p.Min)
+	}
+	if p.Max != "" {
+		result = append(result, "max: "+p.Max)
+	}
+	if p.Secret {
+		result = append(result, "secret")
+	}
+	return result
+}
+
+var docColumns = []string{"Environment variable", "Flag", "YAML key", "Type", "Default", "Constraints", "Description"}
+
+func (p *ParamInfo) docRow() []string {
+	defaultValue := p.Default
+	if p.Secret {
+		defaultValue = "" // secret defaults are not published
+	}
+	return []string{p.EnvName, p.FlagName, p.FileKey, p.Type.String(), defaultValue, strings.Join(p.constraints(), "; "), p.HelpText}
+}
+
+// WriteMarkdown writes reference documentation of configuration parameters in Markdown format,
+// one table per nested section. It can be used with `go generate` to keep documentation up-to-date.
+//   - title - text of the top-level header, omitted if empty
+func (ci *ConfigInfo) WriteMarkdown(w io.Writer, title string) error {
+	escape := strings.NewReplacer("|", `\|`, "\n", " ", "`", "\\`", "*", `\*`, "_", `\_`, "<", "&lt;", ">", "&gt;")
+	var sb strings.Builder
+	if title != "" {
+		fmt.Fprintf(&sb, "# %s\n\n", title)
+	}
+
+	for _, group := range ci.groupBySection() {
+		if group.section != nil {
+			fmt.Fprintf(&sb, "## %s\n\n", group.section.Path)
+			if group.section.HelpText != "" {
+				fmt.Fprintf(&sb, "%s\n\n", escape.Replace(group.section.HelpText))
+			}
+		}
+		sb.WriteString("| " + strings.Join(docColumns, " | ") + " |\n")
+		sb.WriteString(strings.Repeat("|---", len(docColumns)) + "|\n")
+		for _, param := range group.params {
+			row := param.docRow()
+			for idx := range row {
+				if row[idx] != "" && idx < 3 {
+					row[idx] = "`" + strings.ReplaceAll(row[idx], "`", "") + "`"
+				} else {
+					row[idx] = escape.Replace(row[idx])
+				}
+			}
+			sb.WriteString("| " + strings.Join(row, " | ") + " |\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// WriteHTML writes reference documentation of configuration parameters as HTML fragment,
+// one table per nested section
+//   - title - text of the top-level header, omitted if empty
+func (ci *ConfigInfo) WriteHTML(w io.Writer, title string) error {
+	var sb strings.Builder
+	if title != "" {
+		fmt.Fprintf(&sb, "<h1>%s</h1>\n", html.EscapeString(title))
+	}
+
+	for _, group := range ci.groupBySection() {
+		if group.section != nil {
+			fmt.Fprintf(&sb, "<h2>%s</h2>\n", html.EscapeString(group.section.Path))
+			if group.section.HelpText != "" {
+				fmt.Fprintf(&sb, "<p>%s</p>\n", html.EscapeString(group.section.HelpText))
+			}
+		}
+		sb.WriteString("<table>\n<thead><tr>")
+		for _, column := range docColumns {
+			fmt.Fprintf(&sb, "<th>%s</th>", html.EscapeString(column))
+		}
+		sb.WriteString("</tr></thead>\n<tbody>\n")
+		for _, param := range group.params {
+			sb.WriteString("<tr>")
+			for idx, value := range param.docRow() {
+				if value != "" && idx < 3 {
+					fmt.Fprintf(&sb, "<td><code>%s</code></td>", html.EscapeString(value))
+				} else {
+					fmt.Fprintf(&sb, "<td>%s</td>", html.EscapeString(value))
+				}
+			}
+			sb.WriteString("</tr>\n")
+		}
+		sb.WriteString("</tbody>\n</table>\n")
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
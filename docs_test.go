@@ -0,0 +1,92 @@
+package appconfig
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type docsTestCfg struct {
+	ConfigBase `yaml:"-"`
+	Title      string `default:"My App" env:"name" flag:"name" help:"Name of application" required:"true"`
+	HTTP       struct {
+		Address string `default:":8080" flag:"addr" help:"Address to listen <HTTP> requests"`
+		TLS     struct {
+			Mode string `oneof:"off on" help:"TLS | mode"`
+		}
+	} `help:"HTTP server settings"`
+	Password string `secret:"true" min:"8" default:"hunter2"`
+}
+
+func TestConfigInfo_groupBySection(t *testing.T) {
+	t.Parallel()
+	ci, err := NewConfigInfo(&docsTestCfg{}, "APP")
+	require.NoError(t, err)
+
+	groups := ci.groupBySection()
+	require.Len(t, groups, 3)
+
+	var paths []string
+	for _, param := range groups[0].params {
+		paths = append(paths, param.Path)
+	}
+	assert.Nil(t, groups[0].section)
+	assert.Equal(t, []string{"ConfigBase.ShowHelp", "ConfigBase.PrintExample", "ConfigBase.ExampleFormat", "ConfigBase.ConfigFile", "ConfigBase.Completion", "ConfigBase.EnvFile", "Title", "Password"}, paths)
+	assert.Equal(t, "HTTP", groups[1].section.Path)
+	assert.Len(t, groups[1].params, 1)
+	assert.Equal(t, "HTTP.TLS", groups[2].section.Path)
+	assert.Len(t, groups[2].params, 1)
+}
+
+func TestConfigInfo_WriteMarkdown(t *testing.T) {
+	t.Parallel()
+	ci, err := NewConfigInfo(&docsTestCfg{}, "APP")
+	require.NoError(t, err)
+
+	sb := strings.Builder{}
+	require.NoError(t, ci.WriteMarkdown(&sb, "Configuration"))
+
+	expected := "# Configuration\n\n" +
+		"| Environment variable | Flag | YAML key | Type | Default | Constraints | Description |\n" +
+		"|---|---|---|---|---|---|---|\n" +
+		"|  | `--help` |  | bool | false |  | show this help |\n" +
+		"|  | `--example` |  | bool | false |  | show config example |\n" +
+		"|  | `--example-format` |  | string |  | one of: yaml, json, env, flags | format of config example |\n" +
+		"|  | `--config` |  | string |  |  | config file to load |\n" +
+		"|  | `--completion` |  | string |  | one of: bash, zsh, fish | print shell completion script |\n" +
+		"|  | `--env-file` |  | string |  |  | env-file (.env) to load |\n" +
+		"| `APP_NAME` | `--name` | `title` | string | My App | required | Name of application |\n" +
+		"| `APP_PASSWORD` | `--password` | `password` | string |  | min: 8; secret | Password |\n" +
+		"\n" +
+		"## HTTP\n\n" +
+		"HTTP server settings\n\n" +
+		"| Environment variable | Flag | YAML key | Type | Default | Constraints | Description |\n" +
+		"|---|---|---|---|---|---|---|\n" +
+		"| `APP_HTTP_ADDRESS` | `--http-addr` | `http.address` | string | :8080 |  | Address to listen &lt;HTTP&gt; requests |\n" +
+		"\n" +
+		"## HTTP.TLS\n\n" +
+		"| Environment variable | Flag | YAML key | Type | Default | Constraints | Description |\n" +
+		"|---|---|---|---|---|---|---|\n" +
+		"| `APP_HTTP_TLS_MODE` | `--http-tls-mode` | `http.tls.mode` | string |  | one of: off, on | TLS \\| mode |\n" +
+		"\n"
+	assert.Equal(t, expected, sb.String())
+}
+
+func TestConfigInfo_WriteHTML(t *testing.T) {
+	t.Parallel()
+	ci, err := NewConfigInfo(&docsTestCfg{}, "APP")
+	require.NoError(t, err)
+
+	sb := strings.Builder{}
+	require.NoError(t, ci.WriteHTML(&sb, "Configuration"))
+	result := sb.String()
+
+	assert.True(t, strings.HasPrefix(result, "<h1>Configuration</h1>\n<table>\n"))
+	assert.Contains(t, result, "<h2>HTTP</h2>\n<p>HTTP server settings</p>\n<table>")
+	assert.Contains(t, result, "<tr><td><code>APP_HTTP_ADDRESS</code></td><td><code>--http-addr</code></td>"+
+		"<td><code>http.address</code></td><td>string</td><td>:8080</td><td></td><td>Address to listen &lt;HTTP&gt; requests</td></tr>")
+	assert.Equal(t, 3, strings.Count(result, "<table>"))
+	assert.NotContains(t, result, "hunter2")
+}
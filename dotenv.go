@@ -0,0 +1,291 @@
+package appconfig
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// parseDotEnv parses content of .env file. Supported syntax:
+//   - `KEY=value`, optionally prefixed with `export`
+//   - comments: lines starting with `#` and ` # text` after values
+//   - 'single-quoted' values are taken literally
+//   - "double-quoted" values support escapes (\n, \t, \r, \", \\, \$)
+//   - quoted values can be multi-line
+//   - `${VAR}`, `${VAR:-fallback}` and `$VAR` are expanded in unquoted and double-quoted values,
+//     from variables defined above in the file or from `lookupEnv`
+func parseDotEnv(data string, lookupEnv func(string) (string, bool)) (map[string]string, error) {
+	result := map[string]string{}
+	lookup := func(name string) (string, bool, error) {
+		if value, ok := result[name]; ok {
+			return value, true, nil
+		}
+		value, ok := lookupEnv(name)
+		return value, ok, nil
+	}
+
+	p := dotEnvParser{data: data, line: 1}
+	for {
+		p.skipBlank()
+		if p.eof() {
+			return result, nil
+		}
+
+		line := p.line
+		key, value, err := p.readEntry()
+		if err != nil {
+			return nil, &FileError{Line: line, Err: err, op: "parse env-file"}
+		}
+		if value, err = expandVariables(value, lookup); err != nil {
+			return nil, &FileError{Line: line, Err: err, op: "parse env-file"}
+		}
+		result[key] = value
+	}
+}
+
+type dotEnvParser struct {
+	data string
+	pos  int
+	line int
+}
+
+func (p *dotEnvParser) eof() bool {
+	return p.pos >= len(p.data)
+}
+
+func (p *dotEnvParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.data[p.pos]
+}
+
+func (p *dotEnvParser) next() byte {
+	ch := p.data[p.pos]
+	p.pos++
+	if ch == '\n' {
+		p.line++
+	}
+	return ch
+}
+
+func (p *dotEnvParser) skipSpaces() {
+	for ch := p.peek(); ch == ' ' || ch == '\t'; ch = p.peek() {
+		p.next()
+	}
+}
+
+// skipBlank skips empty lines and comment lines
+func (p *dotEnvParser) skipBlank() {
+	for !p.eof() {
+		switch p.peek() {
+		case ' ', '\t', '\r', '\n':
+			p.next()
+		case '#':
+			p.skipLine()
+		default:
+			return
+		}
+	}
+}
+
+func (p *dotEnvParser) skipLine() {
+	for !p.eof() && p.next() != '\n' {
+	}
+}
+
+// endOfEntry checks, that only spaces and comment remain till the end of line
+func (p *dotEnvParser) endOfEntry() error {
+	p.skipSpaces()
+	switch p.peek() {
+	case 0, '\n', '\r', '#':
+		p.skipLine()
+		return nil
+	default:
+		return fmt.Errorf("unexpected character %q after value", p.peek())
+	}
+}
+
+func isEnvNameChar(ch byte, first bool) bool {
+	return ch == '_' || (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') || (!first && (ch == '.' || (ch >= '0' && ch <= '9')))
+}
+
+func (p *dotEnvParser) readName() string {
+	start := p.pos
+	for !p.eof() && isEnvNameChar(p.peek(), p.pos == start) {
+		p.next()
+	}
+	return p.data[start:p.pos]
+}
+
+func (p *dotEnvParser) readEntry() (key string, value string, err error) {
+	key = p.readName()
+	if key == "export" && (p.peek() == ' ' || p.peek() == '\t') {
+		p.skipSpaces()
+		key = p.readName()
+	}
+	if key == "" {
+		return "", "", fmt.Errorf("invalid variable name at %q", p.restOfLine())
+	}
+
+	p.skipSpaces()
+	if p.peek() != '=' {
+		return "", "", fmt.Errorf("expected `=` after %s", key)
+	}
+	p.next()
+	p.skipSpaces()
+
+	switch p.peek() {
+	case '\'':
+		value, err = p.readSingleQuoted()
+	case '"':
+		value, err = p.readDoubleQuoted()
+	default:
+		value = p.readUnquoted()
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("invalid value of %s: %w", key, err)
+	}
+
+	return key, value, p.endOfEntry()
+}
+
+func (p *dotEnvParser) restOfLine() string {
+	text, _, _ := strings.Cut(p.data[p.pos:], "\n")
+	return text
+}
+
+func (p *dotEnvParser) readUnquoted() string {
+	start := p.pos
+	for !p.eof() && p.peek() != '\n' {
+		if p.peek() == '#' && p.pos > start && (p.data[p.pos-1] == ' ' || p.data[p.pos-1] == '\t') {
+			break
+		}
+		p.next()
+	}
+	return strings.TrimRight(p.data[start:p.pos], " \t\r")
+}
+
+func (p *dotEnvParser) readSingleQuoted() (string, error) {
+	p.next() // opening quote
+	start := p.pos
+	for !p.eof() {
+		if p.next() == '\'' {
+			// `$` is doubled to prevent expansion
+			return strings.ReplaceAll(p.data[start:p.pos-1], "$", "$$"), nil
+		}
+	}
+	return "", errors.New("unterminated single-quoted value")
+}
+
+func (p *dotEnvParser) readDoubleQuoted() (string, error) {
+	p.next() // opening quote
+	var sb strings.Builder
+	for !p.eof() {
+		ch := p.next()
+		switch ch {
+		case '"':
+			return sb.String(), nil
+		case '\\':
+			if p.eof() {
+				break
+			}
+			switch escaped := p.next(); escaped {
+			case 'n':
+				sb.WriteByte('\n')
+			case 't':
+				sb.WriteByte('\t')
+			case 'r':
+				sb.WriteByte('\r')
+			case '$':
+				sb.WriteString("$$")
+			case '"', '\\':
+				sb.WriteByte(escaped)
+			default:
+				sb.WriteByte('\\')
+				sb.WriteByte(escaped)
+			}
+		default:
+			sb.WriteByte(ch)
+		}
+	}
+	return "", errors.New("unterminated double-quoted value")
+}
+
+// expandVariables replaces `${NAME}`, `${NAME:-fallback}` and `$NAME` references in the text with values from lookup,
+// `$$` is replaced with single `$`. Fallback is used when the variable is not set or empty.
+func expandVariables(text string, lookup func(name string) (string, bool, error)) (string, error) {
+	if !strings.Contains(text, "$") {
+		return text, nil
+	}
+
+	var sb strings.Builder
+	for pos := 0; pos < len(text); {
+		ch := text[pos]
+		if ch != '$' || pos+1 == len(text) {
+			sb.WriteByte(ch)
+			pos++
+			continue
+		}
+
+		switch next := text[pos+1]; {
+		case next == '$':
+			sb.WriteByte('$')
+			pos += 2
+		case next == '{':
+			end := findClosingBrace(text, pos+2)
+			if end < 0 {
+				return "", fmt.Errorf("unterminated variable reference in %q", text)
+			}
+			name, fallback, hasFallback := strings.Cut(text[pos+2:end], ":-")
+			if name == "" {
+				return "", fmt.Errorf("empty variable name in %q", text)
+			}
+			value, found, err := lookup(name)
+			if err != nil {
+				return "", err
+			}
+			if hasFallback && (!found || value == "") {
+				if value, err = expandVariables(fallback, lookup); err != nil {
+					return "", err
+				}
+			}
+			sb.WriteString(value)
+			pos = end + 1
+		case isEnvNameChar(next, true):
+			end := pos + 2
+			for end < len(text) && isEnvNameChar(text[end], false) && text[end] != '.' {
+				end++
+			}
+			value, _, err := lookup(text[pos+1 : end])
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(value)
+			pos = end
+		default:
+			sb.WriteByte(ch)
+			pos++
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// findClosingBrace returns position of `}` closing the reference started at `start`, taking nested references into account
+func findClosingBrace(text string, start int) int {
+	depth := 0
+	for pos := start; pos < len(text); pos++ {
+		switch {
+		case text[pos] == '$' && pos+1 < len(text) && text[pos+1] == '{':
+			depth++
+			pos++
+		case text[pos] == '}':
+			if depth == 0 {
+				return pos
+			}
+			depth--
+		}
+	}
+	return -1
+}
@@ -0,0 +1,144 @@
+package appconfig
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDotEnv(t *testing.T) {
+	t.Parallel()
+	lookupEnv := func(name string) (string, bool) {
+		if name == "HOME" {
+			return "/home/user", true
+		}
+		return "", false
+	}
+
+	tests := []struct {
+		name    string
+		data    string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "empty",
+			data: "\n# comment only\n\n",
+			want: map[string]string{},
+		},
+		{
+			name: "simple",
+			data: "A=1\nexport B = two words \nC=\n\tD=x#y # comment\r\n",
+			want: map[string]string{"A": "1", "B": "two words", "C": "", "D": "x#y"},
+		},
+		{
+			name: "quoted",
+			data: "A='single $HOME \\n' # comment\nB=\"double \\\"$HOME\\\" \\$HOME\\n\\t\\\\\"\nC=\"multi\nline\"\nD='multi\nline'\n",
+			want: map[string]string{
+				"A": "single $HOME \\n",
+				"B": "double \"/home/user\" $HOME\n\t\\",
+				"C": "multi\nline",
+				"D": "multi\nline",
+			},
+		},
+		{
+			name: "expansion",
+			data: "A=${HOME}/app\nB=$A/cache\nC=${UNKNOWN:-${A}/default}\nD=$UNKNOWN-$$-${HOME:-x}\nE=$1$",
+			want: map[string]string{
+				"A": "/home/user/app",
+				"B": "/home/user/app/cache",
+				"C": "/home/user/app/default",
+				"D": "-$-/home/user",
+				"E": "$1$",
+			},
+		},
+		{name: "no equal sign", data: "A\n", wantErr: true},
+		{name: "invalid name", data: "1A=1\n", wantErr: true},
+		{name: "garbage after value", data: "A='1' 2\n", wantErr: true},
+		{name: "unterminated single", data: "A='1\n", wantErr: true},
+		{name: "unterminated double", data: "A=\"1\n", wantErr: true},
+		{name: "unterminated reference", data: "A=${B\n", wantErr: true},
+		{name: "empty reference", data: "A=${}\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseDotEnv(tt.data, lookupEnv)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestExpandVariables(t *testing.T) {
+	t.Parallel()
+	errLookup := errors.New("lookup failed")
+	lookup := func(name string) (string, bool, error) {
+		switch name {
+		case "A":
+			return "a", true, nil
+		case "EMPTY":
+			return "", true, nil
+		case "ERR":
+			return "", false, errLookup
+		default:
+			return "", false, nil
+		}
+	}
+
+	got, err := expandVariables("$A ${A} ${EMPTY:-e} ${NONE:-${A}} $$A $", lookup)
+	require.NoError(t, err)
+	assert.Equal(t, "a a e a $A $", got)
+
+	_, err = expandVariables("${ERR}", lookup)
+	require.ErrorIs(t, err, errLookup)
+	_, err = expandVariables("$ERR", lookup)
+	require.ErrorIs(t, err, errLookup)
+	_, err = expandVariables("${NONE:-${ERR}}", lookup)
+	require.ErrorIs(t, err, errLookup)
+}
+
+func TestConfigInfo_LoadEnvFile(t *testing.T) {
+	t.Parallel()
+	type testCfg struct {
+		ConfigBase
+		Name    string `default:"app"`
+		Value   int
+		Include struct {
+			SubValue float64
+		}
+	}
+
+	cfg := testCfg{ConfigBase: ConfigBase{EnvFile: "test_cfg.env"}}
+	ci, err := NewConfigInfo(&cfg, "APP")
+	require.NoError(t, err)
+	require.NoError(t, ci.LoadInOrder(&cfg, LoadSourceDefaults, LoadSourceEnvFile))
+
+	assert.Equal(t, "Env file app", cfg.Name)
+	assert.Equal(t, 42, cfg.Value)
+	assert.InDelta(t, 1.5, cfg.Include.SubValue, 0.0001)
+	param, _ := ci.Params().ByPath("Name")
+	assert.Equal(t, LoadSourceEnvFile, param.Source)
+	assert.Equal(t, "env-file", LoadSourceEnvFile.String())
+
+	cfg = testCfg{ConfigBase: ConfigBase{EnvFile: "test_cfg.not_exist"}}
+	require.Error(t, ci.LoadInOrder(&cfg, LoadSourceDefaults, LoadSourceEnvFile))
+
+	cfg = testCfg{ConfigBase: ConfigBase{EnvFile: "test_cfg.invalid"}}
+	require.Error(t, ci.LoadInOrder(&cfg, LoadSourceDefaults, LoadSourceEnvFile))
+
+	// missing file is ignored, when its name is taken from default
+	type defaultCfg struct {
+		EnvFile string `default:"test_cfg.not_exist" use_as_env_file_name:"yes"`
+	}
+	ci, err = NewConfigInfo(&defaultCfg{}, "APP")
+	require.NoError(t, err)
+	require.NoError(t, ci.LoadInOrder(&defaultCfg{}, LoadSourceDefaults, LoadSourceEnvFile))
+}
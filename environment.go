@@ -0,0 +1,78 @@
+package appconfig
+
+import (
+	"io"
+	"io/fs"
+	"os"
+)
+
+// SetArgs sets command-line arguments (without program name) to load flags from, instead of os.Args[1:].
+// nil resets to os.Args[1:], or to arguments left by flag set, bound by BindFlags.
+func (ci *ConfigInfo) SetArgs(args []string) {
+	ci.args = args
+}
+
+// SetLookupEnv sets function to look up environment variables, instead of os.LookupEnv.
+// It is used for loading from environment, reading env-files and interpolation. nil resets to os.LookupEnv.
+func (ci *ConfigInfo) SetLookupEnv(lookup func(name string) (string, bool)) {
+	ci.lookupEnvFunc = lookup
+}
+
+// SetEnv sets map of environment variables, used instead of process environment, see SetLookupEnv
+func (ci *ConfigInfo) SetEnv(env map[string]string) {
+	ci.SetLookupEnv(func(name string) (string, bool) {
+		value, found := env[name]
+		return value, found
+	})
+}
+
+// SetFS sets filesystem to read config file and env-file from, instead of OS filesystem.
+// Names of files must be valid fs.FS paths then, e.g. unrooted and slash-separated. nil resets to OS filesystem.
+func (ci *ConfigInfo) SetFS(fsys fs.FS) {
+	ci.fsys = fsys
+}
+
+// SetOutput sets writer for help, config example and completion script, instead of os.Stdout. nil resets to os.Stdout.
+func (ci *ConfigInfo) SetOutput(w io.Writer) {
+	ci.output = w
+}
+
+// SetStrict enables or disables strict decoding of config file: unknown keys are errors, if enabled
+func (ci *ConfigInfo) SetStrict(strict bool) {
+	ci.strict = strict
+	ci.httpSource = nil
+}
+
+func (ci *ConfigInfo) flagArgs() []string {
+	if ci.args != nil {
+		return ci.args
+	}
+	if ci.flagSet != nil && ci.flagSet.Parsed() {
+		return ci.flagSet.Args()
+	}
+	if len(os.Args) == 0 {
+		return nil
+	}
+	return os.Args[1:]
+}
+
+func (ci *ConfigInfo) writer() io.Writer {
+	if ci.output != nil {
+		return ci.output
+	}
+	return os.Stdout
+}
+
+func (ci *ConfigInfo) lookupEnv(name string) (string, bool) {
+	if ci.lookupEnvFunc != nil {
+		return ci.lookupEnvFunc(name)
+	}
+	return os.LookupEnv(name)
+}
+
+func (ci *ConfigInfo) readFile(name string) ([]byte, error) {
+	if ci.fsys != nil {
+		return fs.ReadFile(ci.fsys, name)
+	}
+	return os.ReadFile(name)
+}
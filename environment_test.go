@@ -0,0 +1,102 @@
+package appconfig
+
+import (
+	"os"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigInfo_Environment(t *testing.T) {
+	t.Parallel()
+	type testCfg struct {
+		ConfigBase
+		Name  string `default:"app"`
+		Port  int
+		Host  string
+		Token string `interpolate:"true"`
+	}
+	fsys := fstest.MapFS{
+		"conf/app.yaml": {Data: []byte("host: example.com\n")},
+		".env":          {Data: []byte("PORT=8080\n")},
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		env      map[string]string
+		expected testCfg
+		wantErr  string
+	}{
+		{
+			name:     "defaults only",
+			expected: testCfg{Name: "app"},
+		},
+		{
+			name: "args, env, config and env-file from fs",
+			args: []string{"--config=conf/app.yaml", "--env-file=.env", "--name=svc"},
+			env:  map[string]string{"TOKEN": "${SECRET}", "SECRET": "s3"},
+			expected: testCfg{
+				ConfigBase: ConfigBase{ConfigFile: "conf/app.yaml", EnvFile: ".env"},
+				Name:       "svc",
+				Port:       8080,
+				Host:       "example.com",
+				Token:      "s3",
+			},
+		},
+		{
+			name:    "missing file in fs",
+			args:    []string{"--config=missing.yaml"},
+			wantErr: "failed to read config file",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := &testCfg{}
+			ci, err := NewConfigInfo(cfg, "")
+			require.NoError(t, err)
+			ci.SetArgs(tt.args)
+			ci.SetEnv(tt.env)
+			ci.SetFS(fsys)
+
+			err = ci.Load(cfg)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, &tt.expected, cfg)
+		})
+	}
+}
+
+func TestConfigInfo_SetLookupEnv(t *testing.T) {
+	t.Parallel()
+	type testCfg struct {
+		Value string
+	}
+	cfg := &testCfg{}
+	ci, err := NewConfigInfo(cfg, "APP")
+	require.NoError(t, err)
+	ci.SetArgs([]string{})
+
+	var requested []string
+	ci.SetLookupEnv(func(name string) (string, bool) {
+		requested = append(requested, name)
+		return "from lookup", true
+	})
+	require.NoError(t, ci.Load(cfg))
+	assert.Equal(t, "from lookup", cfg.Value)
+	assert.Equal(t, []string{"APP_VALUE"}, requested)
+
+	// nil resets to process environment and arguments
+	ci.SetLookupEnv(nil)
+	ci.SetArgs(nil)
+	ci.SetFS(nil)
+	assert.Equal(t, os.Args[1:], ci.flagArgs())
+	_, found := ci.lookupEnv("APP_VALUE_NOT_EXISTING")
+	assert.False(t, found)
+}
@@ -0,0 +1,136 @@
+package appconfig
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// fieldErrorKind is a stage of processing, where FieldError occurred
+type fieldErrorKind byte
+
+const (
+	fieldErrorValidate fieldErrorKind = iota
+	fieldErrorParse
+	fieldErrorLookup
+	fieldErrorInterpolate
+)
+
+// FieldError describes a failure of loading, interpolation or validation of a parameter
+type FieldError struct {
+	Path     string // dot-separated path of the parameter
+	EnvName  string // name of environment variable of the parameter
+	FlagName string // command-line flag of the parameter
+	Source   Source // source of the value
+	Value    string // raw text value, which failed to parse, empty for other failures
+	Err      error  // cause of the failure
+	kind     fieldErrorKind
+}
+
+func newFieldError(kind fieldErrorKind, param *ParamInfo, source Source, value string, err error) *FieldError {
+	return &FieldError{
+		Path:     param.Path,
+		EnvName:  param.EnvName,
+		FlagName: param.FlagName,
+		Source:   source,
+		Value:    value,
+		Err:      err,
+		kind:     kind,
+	}
+}
+
+func (e *FieldError) Error() string {
+	switch e.kind {
+	case fieldErrorParse:
+		return fmt.Sprintf("can't parse %s value `%s` for %s: %v", e.Source, e.Value, e.Path, e.Err)
+	case fieldErrorLookup:
+		return fmt.Sprintf("can't get value of %s from %s: %v", e.Path, e.Source, e.Err)
+	case fieldErrorInterpolate:
+		return fmt.Sprintf("can't interpolate value of %s: %v", e.Path, e.Err)
+	default:
+		return fmt.Sprintf("invalid value of %s: %v", e.Path, e.Err)
+	}
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// FileError describes a failure of reading or parsing config file or env-file
+type FileError struct {
+	File string // name or URL of the file
+	Line int    // number of the line with error, 0 if unknown
+	Err  error  // cause of the failure
+	op   string // failed operation, e.g. "read config file"
+}
+
+func (e *FileError) Error() string {
+	location := e.File
+	if e.Line > 0 {
+		location += ":" + strconv.Itoa(e.Line)
+	}
+	return fmt.Sprintf("failed to %s %s: %v", e.op, location, e.Err)
+}
+
+func (e *FileError) Unwrap() error {
+	return e.Err
+}
+
+// HelpRequestedError is returned by Loader with WithReturnedOutput option, instead of printing help, config example
+// or completion script. It wraps ErrHelpShown, ErrExampleShown or ErrCompletionShown (so ErrStopExpected too).
+type HelpRequestedError struct {
+	text string
+	err  error
+}
+
+func (e *HelpRequestedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *HelpRequestedError) Unwrap() error {
+	return e.err
+}
+
+// Text returns rendered help, config example or completion script
+func (e *HelpRequestedError) Text() string {
+	return e.text
+}
+
+var yamlLineError = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+
+// yamlFileError converts error of YAML decoding into FileError, one per failed line
+func yamlFileError(file string, err error) error {
+	messages := []string{err.Error()}
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) {
+		messages = typeErr.Errors
+	}
+
+	errs := make([]error, 0, len(messages))
+	for _, message := range messages {
+		fileErr := &FileError{File: file, Err: err, op: "unmarshal config file"}
+		if match := yamlLineError.FindStringSubmatch(message); match != nil {
+			fileErr.Line, _ = strconv.Atoi(match[1])
+			fileErr.Err = &yamlError{message: match[2], err: err}
+		}
+		errs = append(errs, fileErr)
+	}
+	return errors.Join(errs...)
+}
+
+// yamlError is a message of YAML decoding error without line number, the original error is kept for errors.As
+type yamlError struct {
+	message string
+	err     error
+}
+
+func (e *yamlError) Error() string {
+	return e.message
+}
+
+func (e *yamlError) Unwrap() error {
+	return e.err
+}
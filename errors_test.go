@@ -0,0 +1,175 @@
+package appconfig
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// collectErrors returns errors of type E, found in the tree of joined errors
+func collectErrors[E error](err error) []E {
+	var result []E
+	var target E
+	if errors.As(err, &target) && error(target) == err {
+		return []E{target}
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, item := range joined.Unwrap() {
+			result = append(result, collectErrors[E](item)...)
+		}
+	} else if wrapped := errors.Unwrap(err); wrapped != nil {
+		result = append(result, collectErrors[E](wrapped)...)
+	}
+	return result
+}
+
+func TestFieldError(t *testing.T) {
+	t.Parallel()
+	type testCfg struct {
+		Port    int  `env:"APP_PORT"`
+		Debug   bool `flag:"debug"`
+		Workers int
+		Name    string `required:"true"`
+	}
+	cfg := &testCfg{}
+	ci, err := NewConfigInfo(cfg, "")
+	require.NoError(t, err)
+	ci.SetArgs([]string{"--debug=maybe", "--workers=many"})
+	ci.SetEnv(map[string]string{"APP_PORT": "http"})
+
+	err = ci.LoadInOrder(cfg, DefaultLoadOrder...)
+	fieldErrs := collectErrors[*FieldError](err)
+	require.Len(t, fieldErrs, 3, "all errors are collected")
+
+	assert.Equal(t, "Debug", fieldErrs[0].Path)
+	assert.Equal(t, "--debug", fieldErrs[0].FlagName)
+	assert.Equal(t, LoadSourceFlags, fieldErrs[0].Source)
+	assert.Equal(t, "maybe", fieldErrs[0].Value)
+	assert.Equal(t, "can't parse flag value `maybe` for Debug: "+fieldErrs[0].Err.Error(), fieldErrs[0].Error())
+	assert.Equal(t, "Workers", fieldErrs[1].Path)
+
+	assert.Equal(t, "Port", fieldErrs[2].Path)
+	assert.Equal(t, "APP_PORT", fieldErrs[2].EnvName)
+	assert.Equal(t, LoadSourceEnvs, fieldErrs[2].Source)
+	assert.Equal(t, "http", fieldErrs[2].Value)
+
+	var fieldErr *FieldError
+	require.ErrorAs(t, err, &fieldErr)
+	assert.Same(t, fieldErrs[0], fieldErr)
+
+	// validation
+	cfg = &testCfg{}
+	require.NoError(t, ci.LoadInOrder(cfg))
+	err = ci.Validate(cfg)
+	require.ErrorAs(t, err, &fieldErr)
+	assert.Equal(t, "Name", fieldErr.Path)
+	assert.Equal(t, LoadSourceNone, fieldErr.Source)
+	assert.Equal(t, "invalid value of Name: value is required", fieldErr.Error())
+
+	// lookup
+	err = ci.LoadInOrder(cfg, &kvSource{err: errors.New("timeout")})
+	require.ErrorAs(t, err, &fieldErr)
+	assert.Equal(t, "can't get value of Port from kv: timeout", fieldErr.Error())
+	assert.Len(t, collectErrors[*FieldError](err), 4)
+}
+
+func TestFieldError_Interpolation(t *testing.T) {
+	t.Parallel()
+	type testCfg struct {
+		A string `default:"${B}" interpolate:"true"`
+		B string `default:"${A}" interpolate:"true"`
+	}
+	cfg := &testCfg{}
+	ci, err := NewConfigInfo(cfg, "")
+	require.NoError(t, err)
+	require.NoError(t, ci.LoadInOrder(cfg, LoadSourceDefaults))
+
+	var fieldErr *FieldError
+	require.ErrorAs(t, ci.Interpolate(cfg), &fieldErr)
+	assert.Equal(t, "B", fieldErr.Path)
+	assert.Equal(t, LoadSourceDefaults, fieldErr.Source)
+	assert.Equal(t, "can't interpolate value of B: interpolation cycle: A -> B -> A", fieldErr.Error())
+}
+
+func TestFileError(t *testing.T) {
+	t.Parallel()
+	type testCfg struct {
+		ConfigBase
+		Port  int
+		Hosts []string
+	}
+	fsys := fstest.MapFS{
+		"syntax.yaml": {Data: []byte("port: 80\nhosts: a: b\n")},
+		"types.yaml":  {Data: []byte("port: http\nhosts:\n  - a\n  - [b]\n")},
+		"bad.env":     {Data: []byte("PORT=80\nHOSTS='a\n")},
+	}
+
+	tests := []struct {
+		name       string
+		args       []string
+		strict     bool
+		wantErr    []FileError
+		message    string
+		typeErrors int
+	}{
+		{
+			name:    "missing file",
+			args:    []string{"--config=missing.yaml"},
+			wantErr: []FileError{{File: "missing.yaml"}},
+			message: "failed to read config file missing.yaml: open missing.yaml: file does not exist",
+		},
+		{
+			name:    "syntax error",
+			args:    []string{"--config=syntax.yaml"},
+			wantErr: []FileError{{File: "syntax.yaml", Line: 2}},
+			message: "failed to unmarshal config file syntax.yaml:2: mapping values are not allowed in this context",
+		},
+		{
+			name:    "type errors",
+			args:    []string{"--config=types.yaml"},
+			wantErr: []FileError{{File: "types.yaml", Line: 1}, {File: "types.yaml", Line: 4}},
+			message: "failed to unmarshal config file types.yaml:1: cannot unmarshal !!str `http` into int\n" +
+				"failed to unmarshal config file types.yaml:4: cannot unmarshal !!seq into string",
+			typeErrors: 2,
+		},
+		{
+			name:    "env-file",
+			args:    []string{"--env-file=bad.env"},
+			wantErr: []FileError{{File: "bad.env", Line: 2}},
+			message: "failed to parse env-file bad.env:2: invalid value of HOSTS: unterminated single-quoted value",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := &testCfg{}
+			ci, err := NewConfigInfo(cfg, "")
+			require.NoError(t, err)
+			ci.SetArgs(tt.args)
+			ci.SetEnv(nil)
+			ci.SetFS(fsys)
+
+			err = ci.Load(cfg)
+			require.EqualError(t, err, tt.message)
+			fileErrs := collectErrors[*FileError](err)
+			require.Len(t, fileErrs, len(tt.wantErr))
+			for idx := range tt.wantErr {
+				assert.Equal(t, tt.wantErr[idx].File, fileErrs[idx].File)
+				assert.Equal(t, tt.wantErr[idx].Line, fileErrs[idx].Line)
+			}
+			if tt.typeErrors > 0 {
+				var typeErr *yaml.TypeError
+				require.ErrorAs(t, err, &typeErr)
+				assert.Len(t, typeErr.Errors, tt.typeErrors)
+			}
+		})
+	}
+
+	err := (&ConfigInfo{configNameParamValue: "missing.yaml", fsys: fsys}).TryLoadConfigFile(&testCfg{})
+	require.ErrorIs(t, err, fs.ErrNotExist)
+}
@@ -0,0 +1,351 @@
+package appconfig
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SecretPlaceholder replaces values of secret parameters in config example
+const SecretPlaceholder = "<secret>"
+
+// Formats of config example
+const (
+	ExampleFormatYAML  = "yaml"  // annotated config file
+	ExampleFormatJSON  = "json"  // config file in JSON format
+	ExampleFormatEnv   = "env"   // .env file with environment variables
+	ExampleFormatFlags = "flags" // command-line arguments list
+)
+
+// isExampleFormat checks that the value is one of supported formats of config example
+func isExampleFormat(value string) bool {
+	switch value {
+	case ExampleFormatYAML, ExampleFormatJSON, ExampleFormatEnv, ExampleFormatFlags:
+		return true
+	default:
+		return false
+	}
+}
+
+// ShowExample showing config example based on `config` data, in the format requested by example-printing flag
+func (ci *ConfigInfo) ShowExample(config any) error {
+	format := ci.ExampleFormat()
+	if format == "" {
+		format = ExampleFormatYAML
+	}
+	return ci.WriteExampleFormat(ci.writer(), config, format)
+}
+
+// WriteExampleFormat writes example based on `config` data in the specified format:
+//   - ExampleFormatYAML - annotated config file, see WriteExample
+//   - ExampleFormatJSON - config file in JSON format
+//   - ExampleFormatEnv - environment variables in .env file format with `help` comments
+//   - ExampleFormatFlags - command-line arguments, ready to paste
+func (ci *ConfigInfo) WriteExampleFormat(w io.Writer, config any, format string) error {
+	switch format {
+	case ExampleFormatYAML:
+		return ci.WriteExample(w, config)
+	case ExampleFormatJSON:
+		return ci.writeJSONExample(w, config)
+	case ExampleFormatEnv:
+		return ci.writeEnvExample(w, config)
+	case ExampleFormatFlags:
+		return ci.writeFlagsExample(w, config, filepath.Base(os.Args[0]))
+	default:
+		return fmt.Errorf("unsupported config example format: %q", format)
+	}
+}
+
+// WriteExample writes self-documenting config file example based on `config` data:
+// each key is commented with `help` text, default and allowed values, required fields are flagged,
+// secrets are replaced by placeholders and nil pointer fields are shown as commented-out entries
+func (ci *ConfigInfo) WriteExample(w io.Writer, config any) error {
+	node, err := ci.exampleNode(config)
+	if err != nil {
+		return fmt.Errorf("failed to marshal config file for printing: %v", err)
+	}
+	data, err := yaml.Marshal(node)
+	if err != nil {
+		return fmt.Errorf("failed to marshal config file for printing: %v", err)
+	}
+	_, err = fmt.Fprintf(w, "# Config file example:\n## >>>>> config file starts here >>>>>\n%s## >>>>> config file ends here <<<<<<\n", string(data))
+
+	return err
+}
+
+func (ci *ConfigInfo) exampleNode(config any) (*yaml.Node, error) {
+	root := new(yaml.Node)
+	if err := root.Encode(config); err != nil {
+		return nil, err
+	}
+
+	for idx := range ci.sections {
+		section := &ci.sections[idx]
+		if section.FileKey == "" || section.HelpText == "" {
+			continue
+		}
+		if mapping, pos := findMappingEntry(root, strings.Split(section.FileKey, ".")); mapping != nil {
+			mapping.Content[pos].HeadComment = section.HelpText
+		}
+	}
+
+	for idx := range ci.params {
+		param := &ci.params[idx]
+		if param.FileKey == "" {
+			continue
+		}
+		mapping, pos := findMappingEntry(root, strings.Split(param.FileKey, "."))
+		if mapping == nil {
+			continue
+		}
+
+		key, value := mapping.Content[pos], mapping.Content[pos+1]
+		if param.Tag.Get("help") != "" {
+			key.HeadComment = param.HelpText
+		}
+		if value.Kind == yaml.ScalarNode || len(value.Content) == 0 {
+			value.LineComment = strings.Join(param.exampleNotes(), "; ")
+		} else {
+			// comment of block collection is printed after its last item, so it is attached to the key
+			key.LineComment = strings.Join(param.exampleNotes(), "; ")
+		}
+		if param.Secret {
+			*value = yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: SecretPlaceholder, LineComment: value.LineComment}
+		}
+		if param.Kind() == reflect.Ptr && value.Tag == "!!null" {
+			commentOutEntry(mapping, pos, param.exampleValue())
+		}
+	}
+
+	return root, nil
+}
+
+// exampleNotes returns default value and constraints of the parameter for commenting in config example
+func (p *ParamInfo) exampleNotes() []string {
+	var result []string
+	if p.Default != "" && !p.Secret {
+		result = append(result, "default: "+p.Default)
+	}
+	for _, text := range p.constraints() {
+		if text != "secret" {
+			result = append(result, text)
+		}
+	}
+	return result
+}
+
+// exampleValue returns sample value for the nil pointer parameter: parsed default or zero value
+func (p *ParamInfo) exampleValue() any {
+	value := reflect.New(p.Type.Elem()).Elem()
+	if p.Default != "" {
+		_ = parseFieldValue(value, p.Default)
+	}
+	return value.Interface()
+}
+
+// findMappingEntry finds the mapping node containing the key sequence, returns the mapping and position of the key
+func findMappingEntry(node *yaml.Node, keys []string) (*yaml.Node, int) {
+	for node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	if node.Kind != yaml.MappingNode || len(keys) == 0 {
+		return nil, 0
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == keys[0] {
+			if len(keys) == 1 {
+				return node, i
+			}
+			return findMappingEntry(node.Content[i+1], keys[1:])
+		}
+	}
+	return nil, 0
+}
+
+// commentOutEntry replaces the mapping entry at `pos` with a comment, containing the key with sample value
+func commentOutEntry(mapping *yaml.Node, pos int, sample any) {
+	key, value := mapping.Content[pos], mapping.Content[pos+1]
+	data, err := yaml.Marshal(map[string]any{key.Value: sample})
+	if err != nil {
+		return
+	}
+	lines := []string{strings.TrimSpace(string(data)) + " # optional"}
+	if value.LineComment != "" {
+		lines[0] += "; " + value.LineComment
+	}
+	if key.HeadComment != "" {
+		lines = append([]string{key.HeadComment}, lines...)
+	}
+	comment := strings.Join(lines, "\n")
+
+	switch {
+	case pos+2 < len(mapping.Content):
+		next := mapping.Content[pos+2]
+		next.HeadComment = strings.TrimSpace(comment + "\n" + next.HeadComment)
+	case pos > 0 && mapping.Content[pos-1].Kind == yaml.ScalarNode:
+		prev := mapping.Content[pos-1]
+		prev.FootComment = strings.TrimSpace(prev.FootComment + "\n" + comment)
+	default:
+		return // nothing to attach the comment to, leave the entry as is
+	}
+	mapping.Content = append(mapping.Content[:pos], mapping.Content[pos+2:]...)
+}
+
+func (ci *ConfigInfo) writeJSONExample(w io.Writer, config any) error {
+	node, err := ci.exampleNode(config)
+	if err != nil {
+		return fmt.Errorf("failed to marshal config file for printing: %v", err)
+	}
+	var value any
+	if err = node.Decode(&value); err != nil {
+		return fmt.Errorf("failed to marshal config file for printing: %v", err)
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(value)
+}
+
+func (ci *ConfigInfo) writeEnvExample(w io.Writer, config any) error {
+	rv := reflect.Indirect(reflect.ValueOf(config))
+	if rv.Kind() != reflect.Struct {
+		return errors.New("value is not a struct or pointer to struct")
+	}
+
+	var sb strings.Builder
+	for idx := range ci.params {
+		param := &ci.params[idx]
+		if param.EnvName == "" || ci.isMagicParam(idx) {
+			continue
+		}
+		value, ok := exampleTextValue(param, rv.FieldByIndex(param.index))
+		if param.Tag.Get("help") != "" {
+			sb.WriteString("# " + strings.ReplaceAll(param.HelpText, "\n", "\n# ") + "\n")
+		}
+		if !ok {
+			sb.WriteString("# ")
+		}
+		sb.WriteString(param.EnvName + "=" + quoteEnvValue(value))
+		if notes := param.exampleNotes(); len(notes) > 0 {
+			sb.WriteString(" # " + strings.Join(notes, "; "))
+		}
+		sb.WriteString("\n")
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func (ci *ConfigInfo) writeFlagsExample(w io.Writer, config any, program string) error {
+	rv := reflect.Indirect(reflect.ValueOf(config))
+	if rv.Kind() != reflect.Struct {
+		return errors.New("value is not a struct or pointer to struct")
+	}
+
+	args := []string{strings.TrimSpace(program + " " + ci.command)}
+	for idx := range ci.params {
+		param := &ci.params[idx]
+		if param.FlagName == "" || ci.isMagicParam(idx) || !ci.isParamSelected(param) {
+			continue
+		}
+		if value, ok := exampleTextValue(param, rv.FieldByIndex(param.index)); ok {
+			args = append(args, quoteShellArg(param.FlagName+"="+value))
+		}
+	}
+	args = append(args, ci.exampleArgs(rv)...)
+
+	_, err := io.WriteString(w, strings.Join(args, " \\\n  ")+"\n")
+	return err
+}
+
+// exampleArgs returns quoted positional arguments of the selected command, preceded by "--" if any starts with "-"
+func (ci *ConfigInfo) exampleArgs(rv reflect.Value) []string {
+	var values []string
+	for _, param := range ci.argParams(ci.command) {
+		field := rv.FieldByIndex(param.index)
+		if param.Arg != ArgRest {
+			value, ok := exampleTextValue(param, field)
+			if !ok {
+				break // following arguments can't be given without this one
+			}
+			values = append(values, value)
+			continue
+		}
+		for idx := 0; idx < field.Len(); idx++ {
+			if value, ok := exampleTextValue(param, field.Index(idx)); ok {
+				values = append(values, value)
+			}
+		}
+	}
+
+	var result []string
+	if slices.ContainsFunc(values, func(value string) bool { return strings.HasPrefix(value, "-") }) {
+		result = append(result, "--")
+	}
+	for _, value := range values {
+		result = append(result, quoteShellArg(value))
+	}
+	return result
+}
+
+// isMagicParam checks that parameter is used to control loading (help, example, config file name, etc.)
+func (ci *ConfigInfo) isMagicParam(idx int) bool {
+	switch idx + 1 {
+	case ci.helpFlagParamNumber, ci.exampleFlagParamNumber, ci.exampleFormatParamNumber, ci.configNameParamNumber,
+		ci.completionParamNumber, ci.envFileParamNumber:
+		return true
+	default:
+		return false
+	}
+}
+
+// exampleTextValue returns text representation of the field value, false if value is unset or can't be represented as text
+func exampleTextValue(param *ParamInfo, field reflect.Value) (string, bool) {
+	if param.Secret {
+		return SecretPlaceholder, true
+	}
+	for field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return param.Default, false
+		}
+		field = field.Elem()
+	}
+	switch field.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return fmt.Sprint(field.Interface()), true
+	default:
+		return "", false
+	}
+}
+
+var (
+	plainShellArg = regexp.MustCompile(`^[a-zA-Z0-9_@%+=:,./-]+$`)
+	plainEnvValue = regexp.MustCompile(`^[a-zA-Z0-9_@%+=:,./-]*$`)
+)
+
+func quoteShellArg(arg string) string {
+	if plainShellArg.MatchString(arg) {
+		return arg
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
+
+func quoteEnvValue(value string) string {
+	if plainEnvValue.MatchString(value) {
+		return value
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "$", `\$`, "\n", `\n`).Replace(value) + `"`
+}
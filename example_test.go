@@ -0,0 +1,255 @@
+package appconfig
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigInfo_WriteExample(t *testing.T) {
+	t.Parallel()
+	type testCfg struct {
+		ConfigBase `yaml:"-"`
+		Title      string `default:"My App" help:"Name of application" required:"true"`
+		Mode       string `default:"dev" oneof:"dev prod"`
+		Timeout    *int   `default:"30" help:"Timeout in seconds"`
+		HTTP       struct {
+			Address  string `default:":8080" help:"Address to listen"`
+			Password string `secret:"true" help:"Basic auth password"`
+			Limit    *uint
+		} `help:"HTTP server settings"`
+		Last *string
+	}
+	cfg := testCfg{Title: "Test", Mode: "prod"}
+	cfg.HTTP.Address = ":80"
+	cfg.HTTP.Password = "qwerty"
+
+	ci, err := NewConfigInfo(&cfg, "APP")
+	require.NoError(t, err)
+
+	sb := strings.Builder{}
+	require.NoError(t, ci.WriteExample(&sb, &cfg))
+
+	expected := "# Config file example:\n" +
+		"## >>>>> config file starts here >>>>>\n" +
+		"# Name of application\n" +
+		"title: Test # default: My App; required\n" +
+		"mode: prod # default: dev; one of: dev, prod\n" +
+		"# Timeout in seconds\n" +
+		"# timeout: 30 # optional; default: 30\n" +
+		"# HTTP server settings\n" +
+		"http:\n" +
+		"    # Address to listen\n" +
+		"    address: :80 # default: :8080\n" +
+		"    # Basic auth password\n" +
+		"    password: <secret>\n" +
+		"    # limit: 0 # optional\n" +
+		"last: null\n" +
+		"## >>>>> config file ends here <<<<<<\n"
+	assert.Equal(t, expected, sb.String())
+	assert.Equal(t, "qwerty", cfg.HTTP.Password)
+
+	// example is a loadable config file
+	loaded := testCfg{}
+	ci.SetStrict(true)
+	ci.SetArgs([]string{"--config=example.yaml"})
+	ci.SetEnv(nil)
+	ci.SetFS(fstest.MapFS{"example.yaml": {Data: []byte(sb.String())}})
+	require.NoError(t, ci.Load(&loaded))
+	assert.Equal(t, "Test", loaded.Title)
+	assert.Equal(t, ":80", loaded.HTTP.Address)
+}
+
+func TestConfigInfo_WriteExampleCollections(t *testing.T) {
+	t.Parallel()
+	type testCfg struct {
+		ConfigBase `yaml:"-"`
+		Tags       []string          `default:"[x,y]"`
+		Ports      []int             `max:"3"`
+		Labels     map[string]string `default:"{a: b}"`
+		HTTP       struct {
+			Port int
+		}
+	}
+	cfg := testCfg{Tags: []string{"x", "y"}, Labels: map[string]string{"a": "b"}}
+
+	ci, err := NewConfigInfo(&cfg, "APP")
+	require.NoError(t, err)
+
+	sb := strings.Builder{}
+	require.NoError(t, ci.WriteExample(&sb, &cfg))
+
+	expected := "# Config file example:\n" +
+		"## >>>>> config file starts here >>>>>\n" +
+		"tags: # default: [x,y]\n" +
+		"    - x\n" +
+		"    - \"y\"\n" +
+		"ports: [] # max: 3\n" +
+		"labels: # default: {a: b}\n" +
+		"    a: b\n" +
+		"http:\n" +
+		"    port: 0\n" +
+		"## >>>>> config file ends here <<<<<<\n"
+	assert.Equal(t, expected, sb.String())
+}
+
+func TestConfigInfo_WriteExampleFormat(t *testing.T) {
+	t.Parallel()
+	type testCfg struct {
+		ConfigBase `yaml:"-"`
+		Title      string `default:"My App" env:"name" flag:"name" help:"Name of application"`
+		Password   string `secret:"true"`
+		Limit      *int   `default:"10" help:"Limit of requests"`
+		Tags       []string
+		HTTP       struct {
+			Address string `default:":8080" flag:"addr" help:"Address to listen"`
+			UseTLS  bool   `help:"Use TLS (HTTPS)"`
+		}
+	}
+	cfg := testCfg{Title: `My "best" app`, Password: "qwerty", Tags: []string{"a"}}
+	cfg.HTTP.Address = ":8080"
+
+	ci, err := NewConfigInfo(&cfg, "APP")
+	require.NoError(t, err)
+
+	tests := []struct {
+		format   string
+		expected string
+		wantErr  bool
+	}{
+		{
+			format: ExampleFormatEnv,
+			expected: "# Name of application\n" +
+				"APP_NAME=\"My \\\"best\\\" app\" # default: My App\n" +
+				"APP_PASSWORD=\"<secret>\"\n" +
+				"# Limit of requests\n" +
+				"# APP_LIMIT=10 # default: 10\n" +
+				"# APP_TAGS=\n" +
+				"# Address to listen\n" +
+				"APP_HTTP_ADDRESS=:8080 # default: :8080\n" +
+				"# Use TLS (HTTPS)\n" +
+				"APP_HTTP_USE_TLS=false\n",
+		},
+		{
+			format: ExampleFormatFlags,
+			expected: "my-app \\\n" +
+				"  '--name=My \"best\" app' \\\n" +
+				"  '--password=<secret>' \\\n" +
+				"  --http-addr=:8080 \\\n" +
+				"  --http-use-tls=false\n",
+		},
+		{
+			format: ExampleFormatJSON,
+			expected: "{\n" +
+				"  \"http\": {\n" +
+				"    \"address\": \":8080\",\n" +
+				"    \"usetls\": false\n" +
+				"  },\n" +
+				"  \"password\": \"<secret>\",\n" +
+				"  \"tags\": [\n" +
+				"    \"a\"\n" +
+				"  ],\n" +
+				"  \"title\": \"My \\\"best\\\" app\"\n" +
+				"}\n",
+		},
+		{
+			format:  "xml",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			t.Parallel()
+			sb := strings.Builder{}
+			err := ci.WriteExampleFormat(&sb, &cfg, tt.format)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if tt.format == ExampleFormatFlags {
+				// program name depends on test binary
+				result := sb.String()
+				sb.Reset()
+				sb.WriteString("my-app" + result[strings.Index(result, " \\\n"):])
+			}
+			assert.Equal(t, tt.expected, sb.String())
+		})
+	}
+}
+
+func TestConfigInfo_ExampleFormat(t *testing.T) {
+	t.Parallel()
+	type boolCfg struct {
+		Example bool `use_as_example_printing_flag:"yes"`
+	}
+
+	ci, err := NewConfigInfo(&boolCfg{}, "")
+	require.NoError(t, err)
+	require.NoError(t, ci.LoadInOrder(&boolCfg{Example: true}))
+	assert.True(t, ci.HasExampleFlag())
+	assert.Equal(t, ExampleFormatYAML, ci.ExampleFormat())
+
+	cfg := struct{ ConfigBase }{ConfigBase{PrintExample: true, ExampleFormat: ExampleFormatEnv}}
+	ci, err = NewConfigInfo(&cfg, "")
+	require.NoError(t, err)
+	require.NoError(t, ci.LoadInOrder(&cfg))
+	assert.Equal(t, ExampleFormatEnv, ci.ExampleFormat())
+
+	cfg.PrintExample = false
+	require.NoError(t, ci.LoadInOrder(&cfg))
+	assert.False(t, ci.HasExampleFlag(), "format alone doesn't request example")
+}
+
+func TestConfigInfo_ExampleFormatFlags(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name           string
+		args           []string
+		expectedFormat string
+		expectedBase   ConfigBase
+	}{
+		{name: "not requested", args: []string{"--example-format=env"}, expectedBase: ConfigBase{ExampleFormat: ExampleFormatEnv}},
+		{name: "default", args: []string{"--example"}, expectedFormat: ExampleFormatYAML, expectedBase: ConfigBase{PrintExample: true}},
+		{name: "bool value", args: []string{"--example=true"}, expectedFormat: ExampleFormatYAML, expectedBase: ConfigBase{PrintExample: true}},
+		{
+			name:           "format value",
+			args:           []string{"--example=json"},
+			expectedFormat: ExampleFormatJSON,
+			expectedBase:   ConfigBase{PrintExample: true, ExampleFormat: ExampleFormatJSON},
+		},
+		{
+			name:           "format flag",
+			args:           []string{"--example", "--example-format=flags"},
+			expectedFormat: ExampleFormatFlags,
+			expectedBase:   ConfigBase{PrintExample: true, ExampleFormat: ExampleFormatFlags},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := struct{ ConfigBase }{}
+			ci, err := NewConfigInfo(&cfg, "")
+			require.NoError(t, err)
+			ci.SetArgs(tt.args)
+			ci.SetEnv(nil)
+
+			require.NoError(t, ci.Load(&cfg))
+			assert.Equal(t, tt.expectedFormat, ci.ExampleFormat())
+			assert.Equal(t, tt.expectedBase, cfg.ConfigBase)
+		})
+	}
+}
+
+func TestQuoting(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "--a=b", quoteShellArg("--a=b"))
+	assert.Equal(t, `'--a=it'\''s'`, quoteShellArg("--a=it's"))
+	assert.Equal(t, "", quoteEnvValue(""))
+	assert.Equal(t, "abc", quoteEnvValue("abc"))
+	assert.Equal(t, `"a b\$c\\\n"`, quoteEnvValue("a b$c\\\n"))
+}
@@ -0,0 +1,140 @@
+package appconfig
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// BindFlags registers global parameters of `config` on the flag set, so flagSet.Parse handles flags of the configuration
+// together with flags of other packages. Every parameter becomes a flag.Value, which writes the value into the field.
+// Old names of parameters from `alias` tags are registered too, their usage is warned.
+// Parsed values and arguments, left by flagSet.Parse, are used as flags source by following loading,
+// so the whole configuration is loaded by Load after flagSet.Parse. Other flags of the set are imported into help,
+// see ImportFlags, and flagSet.Usage is replaced with ShowHelp.
+//   - config - a pointer to structure where the configuration is planned to be loaded
+func (ci *ConfigInfo) BindFlags(flagSet *flag.FlagSet, config any) error {
+	rv := reflect.ValueOf(config)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("value is not a pointer to struct")
+	}
+	rv = rv.Elem()
+
+	var errs []error
+	for idx := range ci.params {
+		param := &ci.params[idx]
+		if param.FlagName == "" || param.Command != "" {
+			continue // flags of commands can't be parsed by flag set
+		}
+		field := rv.FieldByIndex(param.index)
+		names := []string{param.FlagName}
+		for _, alias := range param.Aliases {
+			if alias.FlagName != "" {
+				names = append(names, alias.FlagName)
+			}
+		}
+		for _, flagName := range names {
+			name := strings.TrimPrefix(flagName, "--")
+			if flagSet.Lookup(name) != nil {
+				errs = append(errs, fmt.Errorf("flag %s of parameter %s is already defined", flagName, param.Path))
+				continue
+			}
+			usage := param.HelpText
+			if flagName != param.FlagName {
+				usage = "deprecated, " + param.deprecationText()
+			}
+			flagSet.Var(&paramFlag{ci: ci, param: param, field: field, name: flagName}, name, usage)
+			flagSet.Lookup(name).DefValue = param.Default
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	ci.flagSet = flagSet
+	ci.flagSetValues = map[string]string{}
+	ci.flagSetNames = map[string]string{}
+	ci.ImportFlags(flagSet)
+	flagSet.Usage = func() {
+		_ = ci.WriteHelp(flagSet.Output(), ci.helpOptions)
+	}
+	return nil
+}
+
+// ImportFlags shows flags of the flag set, which are not parameters of the configuration, in help.
+// Flags are taken when help is written, so flags registered later are shown too.
+func (ci *ConfigInfo) ImportFlags(flagSet *flag.FlagSet) {
+	if !slices.Contains(ci.importedFlagSets, flagSet) {
+		ci.importedFlagSets = append(ci.importedFlagSets, flagSet)
+	}
+}
+
+// importedFlags returns flags of imported flag sets as parameters without paths
+func (ci *ConfigInfo) importedFlags() ParamList {
+	var result ParamList
+	for _, flagSet := range ci.importedFlagSets {
+		flagSet.VisitAll(func(f *flag.Flag) {
+			if value, ok := f.Value.(*paramFlag); ok && value.ci == ci {
+				return
+			}
+			result = append(result, ParamInfo{
+				FlagName: "--" + f.Name,
+				HelpText: f.Usage,
+				Default:  f.DefValue,
+			})
+		})
+	}
+	return result
+}
+
+// paramFlag is a flag.Value, which sets value of the parameter
+type paramFlag struct {
+	ci    *ConfigInfo
+	param *ParamInfo
+	field reflect.Value
+	name  string // flag name of the parameter or its old name
+}
+
+// String returns current value of the field
+func (f *paramFlag) String() string {
+	if f.param == nil || !f.field.IsValid() {
+		return "" // zero value, created by flag package
+	}
+	if f.field.Kind() == reflect.Ptr {
+		if f.field.IsNil() {
+			return ""
+		}
+		return fmt.Sprint(f.field.Elem().Interface())
+	}
+	return fmt.Sprint(f.field.Interface())
+}
+
+// Set parses the value into the field and keeps it for following loading.
+// Usage of an old name is warned, different values of the name and an old name are an error.
+func (f *paramFlag) Set(value string) error {
+	name := f.param.FlagName
+	if f.name != name {
+		f.ci.warnOldName(f.param, f.name, name)
+	}
+	if prevName, found := f.ci.flagSetNames[name]; found && prevName != f.name && f.ci.flagSetValues[name] != value {
+		oldName := f.name
+		if oldName == name {
+			oldName = prevName
+		}
+		return fmt.Errorf("conflicting values of %s and deprecated %s", name, oldName)
+	}
+	if err := f.ci.setRawValue(f.param, f.field, value); err != nil {
+		return err
+	}
+	f.ci.flagSetValues[name] = value
+	f.ci.flagSetNames[name] = f.name
+	return nil
+}
+
+// IsBoolFlag allows boolean flags without value, e.g. "-verbose"
+func (f *paramFlag) IsBoolFlag() bool {
+	return f.param != nil && f.param.Kind() == reflect.Bool
+}
@@ -0,0 +1,131 @@
+package appconfig
+
+import (
+	"bytes"
+	"flag"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type flagSetTestCfg struct {
+	ConfigBase
+	Port    int    `default:"8080" help:"port to listen"`
+	Verbose bool   `help:"verbose output"`
+	Name    string `default:"app"`
+	Timeout *int
+}
+
+func TestConfigInfo_BindFlags(t *testing.T) {
+	t.Parallel()
+	cfg := &flagSetTestCfg{}
+	ci, err := NewConfigInfo(cfg, "")
+	require.NoError(t, err)
+	ci.SetEnv(map[string]string{"NAME": "env"})
+
+	flagSet := flag.NewFlagSet("app", flag.ContinueOnError)
+	level := flagSet.Int("v", 0, "log level")
+	require.NoError(t, ci.BindFlags(flagSet, cfg))
+	assert.Equal(t, "8080", flagSet.Lookup("port").DefValue)
+
+	require.NoError(t, flagSet.Parse([]string{"-port=90", "--verbose", "-v=2", "-timeout", "5"}))
+	assert.Equal(t, 2, *level)
+	assert.Equal(t, 90, cfg.Port, "value is written by flag set")
+	assert.Equal(t, "90", flagSet.Lookup("port").Value.String())
+
+	require.NoError(t, ci.Load(cfg))
+	timeout := 5
+	assert.Equal(t, &flagSetTestCfg{Port: 90, Verbose: true, Name: "env", Timeout: &timeout}, cfg)
+	param, _ := ci.Params().ByPath("Port")
+	assert.Equal(t, Source(LoadSourceFlags), param.Source)
+
+	require.ErrorContains(t, flagSet.Parse([]string{"-port=http"}), "invalid value \"http\" for flag -port")
+
+	out := bytes.Buffer{}
+	flagSet.SetOutput(&out)
+	flagSet.Usage()
+	assert.Regexp(t, `--port\s+8080\s+port to listen`, out.String())
+	assert.Contains(t, out.String(), "\nOther flags\n")
+	assert.Regexp(t, `--v\s+0\s+log level`, out.String())
+	assert.Equal(t, 1, strings.Count(out.String(), "--port"))
+}
+
+func TestConfigInfo_BindFlags_Errors(t *testing.T) {
+	t.Parallel()
+	cfg := &flagSetTestCfg{}
+	ci, err := NewConfigInfo(cfg, "")
+	require.NoError(t, err)
+
+	flagSet := flag.NewFlagSet("app", flag.ContinueOnError)
+	flagSet.String("port", "", "")
+	require.ErrorContains(t, ci.BindFlags(flagSet, cfg), "flag --port of parameter Port is already defined")
+	require.ErrorContains(t, ci.BindFlags(flag.NewFlagSet("app", flag.ContinueOnError), *cfg), "not a pointer to struct")
+}
+
+func TestConfigInfo_ImportFlags(t *testing.T) {
+	t.Parallel()
+	ci, err := NewConfigInfo(&flagSetTestCfg{}, "")
+	require.NoError(t, err)
+
+	flagSet := flag.NewFlagSet("lib", flag.ContinueOnError)
+	ci.ImportFlags(flagSet)
+	ci.ImportFlags(flagSet)
+	flagSet.Bool("trace", false, "trace requests") // registered after import
+
+	sb := strings.Builder{}
+	require.NoError(t, ci.WriteHelp(&sb, HelpOptions{Width: 100}))
+	assert.Equal(t, 1, strings.Count(sb.String(), "--trace"))
+	assert.Regexp(t, `\nOther flags\n\s+--trace\s+false\s+trace requests\n$`, sb.String())
+}
+
+func TestConfigInfo_BindFlags_Aliases(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		args     []string
+		address  string
+		warnings int
+		wantErr  string
+	}{
+		{name: "old name", args: []string{"--http-addr=:1"}, address: ":1", warnings: 1},
+		{name: "same values", args: []string{"--http-address=:1", "--http-addr=:1"}, address: ":1", warnings: 1},
+		{
+			name:    "conflicting values",
+			args:    []string{"--http-addr=:1", "--http-address=:2"},
+			wantErr: "conflicting values of --http-address and deprecated --http-addr",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := &deprecatedTestCfg{}
+			ci, err := NewConfigInfo(cfg, "APP")
+			require.NoError(t, err)
+			ci.SetEnv(nil)
+			logs := bytes.Buffer{}
+			ci.SetLogger(slog.New(slog.NewTextHandler(&logs, nil)))
+
+			flagSet := flag.NewFlagSet("app", flag.ContinueOnError)
+			flagSet.SetOutput(&bytes.Buffer{})
+			require.NoError(t, ci.BindFlags(flagSet, cfg))
+			err = flagSet.Parse(tt.args)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, ci.Load(cfg))
+			assert.Equal(t, tt.address, cfg.HTTP.Address)
+			assert.Equal(t, tt.warnings, strings.Count(logs.String(), "name=--http-addr"))
+		})
+	}
+
+	ci, err := NewConfigInfo(&deprecatedTestCfg{}, "")
+	require.NoError(t, err)
+	flagSet := flag.NewFlagSet("app", flag.ContinueOnError)
+	flagSet.String("title", "", "")
+	require.ErrorContains(t, ci.BindFlags(flagSet, &deprecatedTestCfg{}), "flag --title of parameter Name is already defined")
+}
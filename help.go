@@ -0,0 +1,255 @@
+package appconfig
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode/utf8"
+)
+
+// DefaultHelpWidth is used for wrapping help output, when terminal width is unknown
+const DefaultHelpWidth = 100
+
+// HelpOptions contains settings of help output
+type HelpOptions struct {
+	Usage       string             // usage line, e.g. "Usage: my-app [flags]", printed first
+	Description string             // description of the program, printed after usage line
+	Width       int                // width to wrap descriptions, if 0 - taken from COLUMNS environment variable or DefaultHelpWidth
+	Template    *template.Template // custom layout, executed with HelpData
+}
+
+// HelpOptionsProvider can be implemented by configuration structure to supply help options for Load
+type HelpOptionsProvider interface {
+	HelpOptions() HelpOptions
+}
+
+// HelpData is passed to the custom help template
+type HelpData struct {
+	Usage       string
+	Description string
+	Width       int
+	Command     string        // full name of the selected command, empty if no command is selected
+	Commands    []CommandInfo // subcommands of the selected command
+	Sections    []HelpSection
+}
+
+// HelpSection contains parameters of a nested section, Title is empty for root parameters
+type HelpSection struct {
+	Title    string
+	HelpText string
+	Params   ParamList
+}
+
+// SetHelpOptions sets options used by ShowHelp
+func (ci *ConfigInfo) SetHelpOptions(opts HelpOptions) {
+	ci.helpOptions = opts
+}
+
+// ShowHelp showing help
+func (ci *ConfigInfo) ShowHelp() {
+	_ = ci.WriteHelp(ci.writer(), ci.helpOptions)
+}
+
+// WriteHelp writes help of parameters to `w`, grouped by nested sections and wrapped to the width.
+// If a command is selected, only global parameters and parameters of the command and its parents are written,
+// with the list of its subcommands.
+func (ci *ConfigInfo) WriteHelp(w io.Writer, opts HelpOptions) error {
+	data := HelpData{
+		Usage:       opts.Usage,
+		Description: opts.Description,
+		Width:       opts.Width,
+		Command:     ci.command,
+		Commands:    ci.subcommands(ci.command),
+	}
+	if data.Usage == "" {
+		data.Usage = ci.usage()
+	}
+	if data.Width <= 0 {
+		data.Width = ci.terminalWidth()
+	}
+	for _, group := range ci.groupBySection() {
+		section := HelpSection{}
+		if group.section != nil {
+			section.Title = group.section.Path
+			section.HelpText = group.section.HelpText
+		}
+		for _, param := range group.params {
+			if ci.isParamSelected(param) {
+				section.Params = append(section.Params, param.helpParams()...)
+			}
+		}
+		if len(section.Params) > 0 {
+			data.Sections = append(data.Sections, section)
+		}
+	}
+	if imported := ci.importedFlags(); len(imported) > 0 {
+		data.Sections = append(data.Sections, HelpSection{Title: "Other flags", Params: imported})
+	}
+
+	if opts.Template != nil {
+		return opts.Template.Execute(w, data)
+	}
+
+	_, err := io.WriteString(w, data.render())
+	return err
+}
+
+// terminalWidth returns width of terminal from COLUMNS environment variable, DefaultHelpWidth if unknown
+func (ci *ConfigInfo) terminalWidth() int {
+	value, _ := ci.lookupEnv("COLUMNS")
+	if width, err := strconv.Atoi(value); err == nil && width > 0 {
+		return width
+	}
+	return DefaultHelpWidth
+}
+
+const (
+	helpColumnGap    = 2
+	helpMinDescWidth = 20
+)
+
+// helpMaxColumnWidths limits widths of environment, flag and default value columns, longer values are moved to separate lines
+var helpMaxColumnWidths = [3]int{30, 30, 15}
+
+func (data *HelpData) render() string {
+	headers := [3]string{"Environment param", "command-line flag", "default value"}
+	widths := [3]int{}
+	for idx, header := range headers {
+		widths[idx] = utf8.RuneCountInString(header)
+	}
+	for _, section := range data.Sections {
+		for _, param := range section.Params {
+			for idx, value := range param.helpColumns() {
+				widths[idx] = max(widths[idx], min(utf8.RuneCountInString(value), helpMaxColumnWidths[idx]))
+			}
+		}
+	}
+	indent := widths[0] + widths[1] + widths[2] + 3*helpColumnGap
+	descWidth := max(data.Width-indent, helpMinDescWidth)
+
+	var sb strings.Builder
+	writeRow := func(values [3]string, description string) {
+		pos, colStart := 0, 0
+		moveTo := func(column int) {
+			if pos > 0 && pos > column-helpColumnGap {
+				// previous value is too long, continue on the next line
+				sb.WriteString("\n")
+				pos = 0
+			}
+			sb.WriteString(strings.Repeat(" ", column-pos))
+			pos = column
+		}
+		for idx, value := range values {
+			if value != "" {
+				moveTo(colStart)
+				sb.WriteString(value)
+				pos += utf8.RuneCountInString(value)
+			}
+			colStart += widths[idx] + helpColumnGap
+		}
+		for _, text := range wrapText(description, descWidth) {
+			moveTo(indent)
+			sb.WriteString(text)
+			pos = indent + descWidth + helpColumnGap // force next line
+		}
+		sb.WriteString("\n")
+	}
+
+	if data.Usage != "" {
+		sb.WriteString(data.Usage + "\n")
+	}
+	if data.Description != "" {
+		for _, text := range wrapText(data.Description, data.Width) {
+			sb.WriteString(text + "\n")
+		}
+	}
+	if data.Usage != "" || data.Description != "" {
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("List or program parameters\n")
+	writeRow(headers, "description")
+	for _, section := range data.Sections {
+		if section.Title != "" {
+			sb.WriteString("\n" + section.Title)
+			if section.HelpText != "" {
+				sb.WriteString(": " + section.HelpText)
+			}
+			sb.WriteString("\n")
+		}
+		for _, param := range section.Params {
+			writeRow(param.helpColumns(), param.HelpText)
+		}
+	}
+
+	if len(data.Commands) > 0 {
+		sb.WriteString("\nList of commands\n")
+		nameWidth := 0
+		for _, command := range data.Commands {
+			nameWidth = max(nameWidth, utf8.RuneCountInString(command.Name))
+		}
+		descWidth := max(data.Width-nameWidth-helpColumnGap, helpMinDescWidth)
+		for _, command := range data.Commands {
+			lines := wrapText(command.HelpText, descWidth)
+			if len(lines) == 0 {
+				sb.WriteString(command.Name + "\n")
+				continue
+			}
+			for idx, text := range lines {
+				name := ""
+				if idx == 0 {
+					name = command.Name
+				}
+				sb.WriteString(fmt.Sprintf("%-*s", nameWidth+helpColumnGap, name) + text + "\n")
+			}
+		}
+	}
+
+	return sb.String()
+}
+
+// helpColumns returns values of environment, flag and default value columns,
+// name of positional argument is shown instead of the flag
+func (p *ParamInfo) helpColumns() [3]string {
+	flag := p.FlagName
+	if p.ArgName != "" {
+		flag = p.ArgName
+	}
+	return [3]string{p.EnvName, flag, p.Default}
+}
+
+// wrapText splits text into lines of no more than `width` runes, breaking by spaces where possible
+func wrapText(text string, width int) []string {
+	var result []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			switch {
+			case line == "":
+				line = word
+			case utf8.RuneCountInString(line)+1+utf8.RuneCountInString(word) <= width:
+				line += " " + word
+			default:
+				result = append(result, line)
+				line = word
+			}
+		}
+		result = append(result, line)
+	}
+	if len(result) == 1 && result[0] == "" {
+		return nil
+	}
+	return result
+}
+
+// HelpTemplateFuncs returns functions useful in custom help templates:
+//   - wrap WIDTH TEXT - wraps text to lines of no more than WIDTH runes
+//   - pad WIDTH TEXT - pads text with spaces to WIDTH runes
+func HelpTemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"wrap": func(width int, text string) string { return strings.Join(wrapText(text, width), "\n") },
+		"pad":  func(width int, text string) string { return fmt.Sprintf("%-*s", width, text) },
+	}
+}
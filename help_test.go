@@ -0,0 +1,130 @@
+package appconfig
+
+import (
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type helpTestCfg struct {
+	Title string `default:"My App" env:"name" flag:"name" help:"Name of application, which is shown in the title of the main window"`
+	HTTP  struct {
+		Address string `default:":8080" flag:"addr" help:"Address to listen HTTP requests"`
+		UseTLS  bool   `help:"Use TLS (HTTPS)"`
+	} `help:"HTTP server settings"`
+	DB struct {
+		ConnectionStringForPrimaryDatabase string `help:"DSN"`
+	}
+}
+
+func (helpTestCfg) HelpOptions() HelpOptions {
+	return HelpOptions{Usage: "Usage: my-app [flags]", Width: 80}
+}
+
+func TestConfigInfo_WriteHelp(t *testing.T) {
+	t.Parallel()
+	ci, err := NewConfigInfo(&helpTestCfg{}, "APP")
+	require.NoError(t, err)
+
+	sb := strings.Builder{}
+	require.NoError(t, ci.WriteHelp(&sb, HelpOptions{
+		Usage:       "Usage: my-app [flags]",
+		Description: "My application does useful things",
+		Width:       80,
+	}))
+
+	expected := "Usage: my-app [flags]\n" +
+		"My application does useful things\n" +
+		"\n" +
+		"List or program parameters\n" +
+		"Environment param               command-line flag               default value  description\n" +
+		"APP_NAME                        --name                          My App         Name of application,\n" +
+		"                                                                               which is shown in\n" +
+		"                                                                               the title of the\n" +
+		"                                                                               main window\n" +
+		"\n" +
+		"HTTP: HTTP server settings\n" +
+		"APP_HTTP_ADDRESS                --http-addr                     :8080          Address to listen\n" +
+		"                                                                               HTTP requests\n" +
+		"APP_HTTP_USE_TLS                --http-use-tls                                 Use TLS (HTTPS)\n" +
+		"\n" +
+		"DB\n" +
+		"APP_DB_CONNECTION_STRING_FOR_PRIMARY_DATABASE\n" +
+		"                                --db-connection-string-for-primary-database    DSN\n"
+	assert.Equal(t, expected, sb.String())
+}
+
+func TestConfigInfo_WriteHelpTemplate(t *testing.T) {
+	t.Parallel()
+	ci, err := NewConfigInfo(&helpTestCfg{}, "APP")
+	require.NoError(t, err)
+
+	tmpl := template.Must(template.New("help").Funcs(HelpTemplateFuncs()).Parse(
+		"{{.Usage}}\n{{range .Sections}}[{{.Title}}]\n{{range .Params}}{{pad 20 .FlagName}}{{.HelpText}}\n{{end}}{{end}}"))
+
+	sb := strings.Builder{}
+	require.NoError(t, ci.WriteHelp(&sb, HelpOptions{Usage: "Usage: my-app", Template: tmpl}))
+
+	expected := "Usage: my-app\n" +
+		"[]\n" +
+		"--name              Name of application, which is shown in the title of the main window\n" +
+		"[HTTP]\n" +
+		"--http-addr         Address to listen HTTP requests\n" +
+		"--http-use-tls      Use TLS (HTTPS)\n" +
+		"[DB]\n" +
+		"--db-connection-string-for-primary-databaseDSN\n"
+	assert.Equal(t, expected, sb.String())
+}
+
+func TestHelpOptionsProvider(t *testing.T) {
+	t.Parallel()
+	ci, err := NewConfigInfo(helpTestCfg{}, "APP")
+	require.NoError(t, err)
+	assert.Equal(t, HelpOptions{Usage: "Usage: my-app [flags]", Width: 80}, ci.helpOptions)
+
+	ci.SetHelpOptions(HelpOptions{Width: 10})
+	assert.Equal(t, HelpOptions{Width: 10}, ci.helpOptions)
+}
+
+func TestWrapText(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		text  string
+		width int
+		want  []string
+	}{
+		{"empty", "", 10, nil},
+		{"short", "one two", 10, []string{"one two"}},
+		{"wrapped", "one two three four", 9, []string{"one two", "three", "four"}},
+		{"long word", "abcdefghijkl mn", 5, []string{"abcdefghijkl", "mn"}},
+		{"paragraphs", "one\ntwo three", 20, []string{"one", "two three"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, wrapText(tt.text, tt.width))
+		})
+	}
+}
+
+func TestConfigInfo_terminalWidth(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		env  map[string]string
+		want int
+	}{
+		{env: map[string]string{"COLUMNS": "60"}, want: 60},
+		{env: map[string]string{"COLUMNS": "wide"}, want: DefaultHelpWidth},
+		{env: nil, want: DefaultHelpWidth},
+	}
+	for _, tt := range tests {
+		ci := &ConfigInfo{}
+		ci.SetEnv(tt.env)
+		assert.Equal(t, tt.want, ci.terminalWidth())
+	}
+}
@@ -0,0 +1,143 @@
+package appconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// ChangeHandler receives previous and new configuration, and paths of changed parameters
+type ChangeHandler[T any] func(oldCfg, newCfg *T, changed []string)
+
+// Holder holds loaded configuration and allows to replace it safely.
+// Get is lock-free, Reload runs the full load pipeline into a fresh structure, validates it and atomically publishes it.
+// If reloading fails, the previous configuration is kept.
+// Parameters tagged `reload:"false"` are immutable: reloading, which changes them, is rejected.
+type Holder[T any] struct {
+	current atomic.Pointer[T]
+
+	mu          sync.Mutex // serializes reloading and protects fields below
+	loader      *Loader
+	ci          *ConfigInfo
+	subscribers []ChangeHandler[T]
+}
+
+// NewHolder loads configuration as Load does, and creates a holder for it
+//   - envPrefix - a common prefix for environment variables, as in Load
+func NewHolder[T any](envPrefix string) (*Holder[T], error) {
+	return NewHolderWithLoader[T](NewLoader(WithEnvPrefix(envPrefix)))
+}
+
+// NewHolderWithLoader loads configuration with the loader, and creates a holder for it.
+// The loader is used for reloading too.
+func NewHolderWithLoader[T any](loader *Loader) (*Holder[T], error) {
+	h := &Holder[T]{}
+	if err := h.init(loader); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+func (h *Holder[T]) init(loader *Loader) error {
+	cfg := new(T)
+	ci, err := loader.loadWithInfo(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	h.loader = loader
+	h.ci = ci
+	h.current.Store(cfg)
+	return nil
+}
+
+// Get returns current configuration, it must not be modified
+func (h *Holder[T]) Get() *T {
+	return h.current.Load()
+}
+
+// Subscribe adds handler, called after new configuration is published.
+// Handlers are called without holding the lock, so they may call Reload or Subscribe;
+// handlers of concurrent reloadings may be called concurrently.
+func (h *Holder[T]) Subscribe(handler ChangeHandler[T]) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.subscribers = append(h.subscribers, handler)
+}
+
+// Reload loads configuration into a fresh structure and publishes it, if loading and validation succeeded,
+// any parameter is changed and no immutable parameter is changed. Subscribers are notified after publishing.
+func (h *Holder[T]) Reload() error {
+	h.mu.Lock()
+	notify, err := h.reload()
+	h.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	notify()
+
+	return nil
+}
+
+// reload loads and publishes configuration, returns function, which notifies subscribers about the change.
+// It has to be called with the lock held, the notification - after unlocking.
+func (h *Holder[T]) reload() (func(), error) {
+	cfg := new(T)
+	if err := h.loader.load(context.Background(), h.ci, cfg); err != nil {
+		return nil, err
+	}
+
+	oldCfg := h.current.Load()
+	changed := h.ci.changedPaths(oldCfg, cfg)
+	if err := h.ci.checkImmutable(changed); err != nil {
+		return nil, err
+	}
+	if len(changed) == 0 {
+		return func() {}, nil
+	}
+	h.current.Store(cfg)
+	subscribers := slices.Clone(h.subscribers)
+
+	return func() {
+		for _, handler := range subscribers {
+			handler(oldCfg, cfg, changed)
+		}
+	}, nil
+}
+
+// changedPaths returns paths of parameters, which values differ in two configurations of the same type
+func (ci *ConfigInfo) changedPaths(oldCfg, newCfg any) []string {
+	oldValue := reflect.Indirect(reflect.ValueOf(oldCfg))
+	newValue := reflect.Indirect(reflect.ValueOf(newCfg))
+	var result []string
+	for idx := range ci.params {
+		index := ci.params[idx].index
+		if !reflect.DeepEqual(oldValue.FieldByIndex(index).Interface(), newValue.FieldByIndex(index).Interface()) {
+			result = append(result, ci.params[idx].Path)
+		}
+	}
+	return result
+}
+
+// checkImmutable returns error, if any of changed parameters is tagged `reload:"false"`
+func (ci *ConfigInfo) checkImmutable(changed []string) error {
+	var errs []error
+	for _, path := range changed {
+		if param, ok := ci.params.ByPath(path); ok && !isReloadable(&param) {
+			errs = append(errs, fmt.Errorf("parameter %s can't be changed without restart", path))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func isReloadable(param *ParamInfo) bool {
+	value := param.Tag.Get("reload")
+	if value == "" {
+		return true
+	}
+	reloadable, err := parseBool(value)
+	return err != nil || reloadable
+}
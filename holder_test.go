@@ -0,0 +1,121 @@
+package appconfig
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type holderTestCfg struct {
+	Listen string `default:"localhost:80" reload:"false"`
+	Level  string `default:"info"`
+}
+
+func TestHolder(t *testing.T) {
+	t.Setenv("HOLDER_TEST_LEVEL", "debug")
+
+	h, err := NewHolder[holderTestCfg]("HOLDER_TEST")
+	require.NoError(t, err)
+	first := h.Get()
+	require.Equal(t, &holderTestCfg{Listen: "localhost:80", Level: "debug"}, first)
+
+	var changes [][]string
+	h.Subscribe(func(oldCfg, newCfg *holderTestCfg, changed []string) {
+		assert.Same(t, first, oldCfg)
+		assert.Same(t, h.Get(), newCfg)
+		changes = append(changes, changed)
+	})
+
+	// nothing changed
+	require.NoError(t, h.Reload())
+	assert.Empty(t, changes)
+
+	// mutable parameter changed
+	t.Setenv("HOLDER_TEST_LEVEL", "warn")
+	require.NoError(t, h.Reload())
+	assert.Equal(t, [][]string{{"Level"}}, changes)
+	assert.Equal(t, "warn", h.Get().Level)
+	assert.Equal(t, "debug", first.Level)
+
+	// immutable parameter changed
+	second := h.Get()
+	first = second
+	t.Setenv("HOLDER_TEST_LISTEN", "localhost:81")
+	t.Setenv("HOLDER_TEST_LEVEL", "error")
+	err = h.Reload()
+	require.EqualError(t, err, "parameter Listen can't be changed without restart")
+	assert.Same(t, second, h.Get())
+	assert.Len(t, changes, 1)
+}
+
+func TestHolder_Concurrent(t *testing.T) {
+	t.Setenv("HOLDER_CONCURRENT_LEVEL", "debug")
+
+	h, err := NewHolder[holderTestCfg]("HOLDER_CONCURRENT")
+	require.NoError(t, err)
+
+	wg := sync.WaitGroup{}
+	for range 4 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, h.Reload())
+		}()
+		go func() {
+			defer wg.Done()
+			assert.Equal(t, "debug", h.Get().Level)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestHolder_ReentrantHandler(t *testing.T) {
+	t.Setenv("HOLDER_REENTRANT_LEVEL", "debug")
+
+	h, err := NewHolder[holderTestCfg]("HOLDER_REENTRANT")
+	require.NoError(t, err)
+
+	var calls int
+	h.Subscribe(func(_, _ *holderTestCfg, _ []string) {
+		calls++
+		h.Subscribe(func(_, _ *holderTestCfg, _ []string) {})
+		assert.NoError(t, h.Reload(), "nothing is changed by nested reloading")
+	})
+
+	t.Setenv("HOLDER_REENTRANT_LEVEL", "warn")
+	done := make(chan error)
+	go func() { done <- h.Reload() }()
+	select {
+	case err = <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "handler, calling Reload, is deadlocked")
+	}
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, "warn", h.Get().Level)
+}
+
+func TestIsReloadable(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		tag  string
+		want bool
+	}{
+		{name: "no tag", tag: ``, want: true},
+		{name: "true", tag: `reload:"true"`, want: true},
+		{name: "false", tag: `reload:"false"`, want: false},
+		{name: "no", tag: `reload:"no"`, want: false},
+		{name: "invalid", tag: `reload:"sometimes"`, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, isReloadable(&ParamInfo{Tag: reflect.StructTag(tt.tag)}))
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package appconfig
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// DefaultHTTPTimeout is the default timeout of a request to config server
+	DefaultHTTPTimeout = 10 * time.Second
+	// DefaultHTTPRetryDelay is the default delay between retries of failed requests to config server
+	DefaultHTTPRetryDelay = time.Second
+)
+
+// HTTPSourceOptions contains settings of loading configuration from HTTP server
+type HTTPSourceOptions struct {
+	Client     *http.Client  // client to make requests, http.DefaultClient if nil
+	Timeout    time.Duration // timeout of every request, DefaultHTTPTimeout if 0
+	Retries    int           // number of retries of failed request (network errors, 5xx and 429 responses), 0 if negative
+	RetryDelay time.Duration // delay between retries, DefaultHTTPRetryDelay if 0
+	CacheFile  string        // file to keep last-known-good config, used on startup, when server is unavailable
+	Strict     bool          // unknown keys in config are errors
+	Logger     *slog.Logger  // logger for warnings about old keys of parameters, slog.Default() if nil
+}
+
+// HTTPSource is a DecodeSource, which fetches YAML or JSON config from URL.
+// Repeated fetches are revalidated with ETag/If-Modified-Since, so unchanged config is not transferred again.
+// Successfully decoded config is saved into cache file, if specified, and is taken from it, when server is unavailable
+// on startup.
+type HTTPSource struct {
+	url         string
+	opts        HTTPSourceOptions
+	interpolate func(root *yaml.Node) (bool, error) // interpolation of values, set for config file parameter
+
+	mu           sync.Mutex // protects fields below
+	data         []byte
+	etag         string
+	lastModified string
+}
+
+// NewHTTPSource creates a source, fetching config from `url`
+func NewHTTPSource(url string, opts HTTPSourceOptions) *HTTPSource {
+	if opts.Client == nil {
+		opts.Client = http.DefaultClient
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultHTTPTimeout
+	}
+	if opts.RetryDelay <= 0 {
+		opts.RetryDelay = DefaultHTTPRetryDelay
+	}
+	opts.Retries = max(opts.Retries, 0)
+	if opts.Logger == nil {
+		opts.Logger = slog.Default()
+	}
+	return &HTTPSource{url: url, opts: opts}
+}
+
+// String returns URL of the source
+func (s *HTTPSource) String() string {
+	return s.url
+}
+
+// Decode fetches config and decodes it into `config`, returns paths of parameters present in config
+func (s *HTTPSource) Decode(config any, params ParamList) ([]string, error) {
+	return s.DecodeContext(context.Background(), config, params)
+}
+
+// DecodeContext does the same as Decode, requests are canceled with the context
+func (s *HTTPSource) DecodeContext(ctx context.Context, config any, params ParamList) ([]string, error) {
+	data, fromCache, err := s.fetchOrCached(ctx)
+	if err != nil {
+		return nil, err
+	}
+	paths, err := decodeConfigData(s.url, data, config, params, s.opts.Strict, s.opts.Logger, s.interpolate)
+	if err != nil {
+		return nil, err
+	}
+	if !fromCache && s.opts.CacheFile != "" {
+		s.mu.Lock()
+		_ = writeFileAtomic(s.opts.CacheFile, data) // cache is optional, loading does not depend on it
+		s.mu.Unlock()
+	}
+	return paths, nil
+}
+
+// Fetch returns content of config: requested from server, or taken from cache file, if server is unavailable
+// and config was not fetched before
+func (s *HTTPSource) Fetch() ([]byte, error) {
+	return s.FetchContext(context.Background())
+}
+
+// FetchContext does the same as Fetch, requests are canceled with the context
+func (s *HTTPSource) FetchContext(ctx context.Context) ([]byte, error) {
+	data, _, err := s.fetchOrCached(ctx)
+	return data, err
+}
+
+// fetchOrCached returns content of config and flag that it is taken from cache file
+func (s *HTTPSource) fetchOrCached(ctx context.Context) ([]byte, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := s.fetch(ctx)
+	if err == nil {
+		return data, false, nil
+	}
+	if s.data == nil && s.opts.CacheFile != "" {
+		if cached, errCache := os.ReadFile(s.opts.CacheFile); errCache == nil {
+			return cached, true, nil
+		}
+	}
+	return nil, false, &FileError{File: s.url, Err: err, op: "fetch config from"}
+}
+
+func (s *HTTPSource) fetch(ctx context.Context) ([]byte, error) {
+	var err error
+	for attempt := 0; attempt <= s.opts.Retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(s.opts.RetryDelay):
+			}
+		}
+		var data []byte
+		var retry bool
+		if data, retry, err = s.request(ctx); err == nil {
+			return data, nil
+		}
+		if !retry {
+			break
+		}
+	}
+	return nil, err
+}
+
+// request makes a single request, returns data of config, or error and flag of retrying possibility
+func (s *HTTPSource) request(ctx context.Context) (data []byte, retry bool, err error) {
+	ctx, cancel := context.WithTimeout(ctx, s.opts.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	if s.data != nil {
+		if s.etag != "" {
+			req.Header.Set("If-None-Match", s.etag)
+		}
+		if s.lastModified != "" {
+			req.Header.Set("If-Modified-Since", s.lastModified)
+		}
+	}
+
+	resp, err := s.opts.Client.Do(req)
+	if err != nil {
+		return nil, true, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && s.data != nil:
+		return s.data, false, nil
+	case resp.StatusCode == http.StatusOK:
+		if data, err = io.ReadAll(resp.Body); err != nil {
+			return nil, true, err
+		}
+		s.data = data
+		s.etag = resp.Header.Get("ETag")
+		s.lastModified = resp.Header.Get("Last-Modified")
+		return data, false, nil
+	default:
+		retry = resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests
+		return nil, retry, fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+}
+
+// writeFileAtomic writes data into temporary file and renames it to `name`,
+// so the file is never left partially written
+func writeFileAtomic(name string, data []byte) error {
+	file, err := os.CreateTemp(filepath.Dir(name), filepath.Base(name)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(data)
+	if errClose := file.Close(); err == nil {
+		err = errClose
+	}
+	if err == nil {
+		err = os.Rename(file.Name(), name)
+	}
+	if err != nil {
+		_ = os.Remove(file.Name())
+	}
+	return err
+}
+
+// SetHTTPSourceOptions sets options of loading config by URL, specified in `use_as_config_file_name` parameter
+func (ci *ConfigInfo) SetHTTPSourceOptions(opts HTTPSourceOptions) {
+	ci.httpOptions = opts
+	ci.httpSource = nil
+}
+
+// httpSourceFor returns source for the URL, keeping state of revalidation between loadings
+func (ci *ConfigInfo) httpSourceFor(url string) *HTTPSource {
+	if ci.httpSource == nil || ci.httpSource.url != url {
+		opts := ci.httpOptions
+		opts.Strict = opts.Strict || ci.strict
+		if opts.Logger == nil {
+			opts.Logger = ci.logger
+		}
+		ci.httpSource = NewHTTPSource(url, opts)
+		ci.httpSource.interpolate = ci.interpolateNodes
+	}
+	return ci.httpSource
+}
+
+// isConfigURL checks that config name is URL of HTTP server
+func isConfigURL(name string) bool {
+	return strings.HasPrefix(name, "http://") || strings.HasPrefix(name, "https://")
+}
@@ -0,0 +1,225 @@
+package appconfig
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type httpSourceTestCfg struct {
+	ConfigBase
+	Name string
+	Port int
+}
+
+func TestHTTPSource_Decode(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		expected    httpSourceTestCfg
+		loaded      []string
+	}{
+		{
+			name:     "yaml",
+			body:     "name: yaml\nport: 80\n",
+			expected: httpSourceTestCfg{Name: "yaml", Port: 80},
+			loaded:   []string{"Name", "Port"},
+		},
+		{
+			name:     "json",
+			body:     `{"port": 81}`,
+			expected: httpSourceTestCfg{Port: 81},
+			loaded:   []string{"Port"},
+		},
+		{
+			name: "empty",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			cfg := httpSourceTestCfg{}
+			ci, err := NewConfigInfo(&cfg, "")
+			require.NoError(t, err)
+			source := NewHTTPSource(server.URL, HTTPSourceOptions{})
+			assert.Equal(t, server.URL, source.String())
+
+			loaded, err := source.Decode(&cfg, ci.Params())
+			require.NoError(t, err)
+			assert.Equal(t, tt.loaded, loaded)
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestHTTPSource_Revalidation(t *testing.T) {
+	t.Parallel()
+	const lastModified = "Mon, 19 Oct 2026 10:00:00 GMT"
+	var requests, transfers atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Header.Get("If-None-Match") == `"v1"` && r.Header.Get("If-Modified-Since") == lastModified {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		transfers.Add(1)
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", lastModified)
+		_, _ = w.Write([]byte("port: 80\n"))
+	}))
+	defer server.Close()
+
+	source := NewHTTPSource(server.URL, HTTPSourceOptions{})
+	for range 3 {
+		data, err := source.Fetch()
+		require.NoError(t, err)
+		assert.Equal(t, "port: 80\n", string(data))
+	}
+	assert.Equal(t, int32(3), requests.Load())
+	assert.Equal(t, int32(1), transfers.Load())
+}
+
+func TestHTTPSource_Retries(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		status   int
+		failures int32
+		retries  int
+		requests int32
+		wantErr  string
+	}{
+		{name: "success after retries", status: http.StatusServiceUnavailable, failures: 2, retries: 2, requests: 3},
+		{name: "retries exceeded", status: http.StatusServiceUnavailable, failures: 2, retries: 1, requests: 2, wantErr: "503 Service Unavailable"},
+		{name: "too many requests", status: http.StatusTooManyRequests, failures: 1, retries: 1, requests: 2},
+		{name: "not retried", status: http.StatusNotFound, failures: 1, retries: 3, requests: 1, wantErr: "404 Not Found"},
+		{name: "negative retries", status: http.StatusServiceUnavailable, failures: 1, retries: -1, requests: 1, wantErr: "503 Service Unavailable"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				if requests.Add(1) <= tt.failures {
+					w.WriteHeader(tt.status)
+					return
+				}
+				_, _ = w.Write([]byte("port: 80\n"))
+			}))
+			defer server.Close()
+
+			source := NewHTTPSource(server.URL, HTTPSourceOptions{Retries: tt.retries, RetryDelay: time.Millisecond})
+			_, err := source.Fetch()
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.requests, requests.Load())
+		})
+	}
+}
+
+func TestHTTPSource_Timeout(t *testing.T) {
+	t.Parallel()
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	source := NewHTTPSource(server.URL, HTTPSourceOptions{Timeout: 10 * time.Millisecond})
+	_, err := source.Fetch()
+	require.ErrorContains(t, err, "context deadline exceeded")
+}
+
+func TestHTTPSource_Cache(t *testing.T) {
+	t.Parallel()
+	cacheFile := filepath.Join(t.TempDir(), "config.cache")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("port: 80\n"))
+	}))
+	url := server.URL
+
+	_, err := NewHTTPSource(url, HTTPSourceOptions{CacheFile: cacheFile}).Fetch()
+	require.NoError(t, err)
+	require.NoFileExists(t, cacheFile, "cache is written after decoding only")
+	_, err = NewHTTPSource(url, HTTPSourceOptions{CacheFile: cacheFile}).Decode(&struct{ Port int }{}, nil)
+	require.NoError(t, err)
+	server.Close()
+
+	// server is down on startup
+	data, err := NewHTTPSource(url, HTTPSourceOptions{CacheFile: cacheFile}).Fetch()
+	require.NoError(t, err)
+	assert.Equal(t, "port: 80\n", string(data))
+
+	_, err = NewHTTPSource(url, HTTPSourceOptions{CacheFile: cacheFile + ".missing"}).Fetch()
+	require.ErrorContains(t, err, "failed to fetch config from "+url)
+}
+
+func TestHTTPSource_CacheKeepsLastGood(t *testing.T) {
+	t.Parallel()
+	cacheFile := filepath.Join(t.TempDir(), "config.cache")
+	require.NoError(t, os.WriteFile(cacheFile, []byte("port: 80\n"), 0o600))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("port: [broken\n"))
+	}))
+	defer server.Close()
+
+	_, err := NewHTTPSource(server.URL, HTTPSourceOptions{CacheFile: cacheFile}).Decode(&struct{ Port int }{}, nil)
+	require.Error(t, err)
+	data, err := os.ReadFile(cacheFile)
+	require.NoError(t, err)
+	assert.Equal(t, "port: 80\n", string(data))
+}
+
+func TestConfigInfo_LoadFromURL(t *testing.T) {
+	t.Parallel()
+	var body atomic.Value
+	body.Store("name: remote\nport: 80\n")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(body.Load().(string)))
+	}))
+	defer server.Close()
+
+	cfg := &httpSourceTestCfg{}
+	ci, err := NewConfigInfo(cfg, "")
+	require.NoError(t, err)
+	ci.SetArgs([]string{"--config=" + server.URL})
+	ci.SetEnv(nil)
+	ci.SetHTTPSourceOptions(HTTPSourceOptions{Timeout: time.Second})
+
+	require.NoError(t, ci.Load(cfg))
+	assert.Equal(t, "remote", cfg.Name)
+	assert.Equal(t, 80, cfg.Port)
+	param, _ := ci.Params().ByPath("Port")
+	assert.Equal(t, LoadSourceFile, param.Source)
+	source := ci.httpSource
+
+	body.Store("name: remote\nport: 81\n")
+	cfg = &httpSourceTestCfg{}
+	require.NoError(t, ci.Load(cfg))
+	assert.Equal(t, 81, cfg.Port)
+	assert.Same(t, source, ci.httpSource)
+
+	server.Close()
+	require.ErrorContains(t, ci.Load(&httpSourceTestCfg{}), "failed to fetch config from "+server.URL)
+}
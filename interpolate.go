@@ -0,0 +1,237 @@
+package appconfig
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SetInterpolation enables or disables variables interpolation for all parameters.
+// Interpolation of a single parameter can be enabled or disabled by `interpolate:"true"` or `interpolate:"false"` tag.
+//
+// Supported references are `${VAR}`, `${VAR:-fallback}` and `$VAR` for environment variables,
+// and `${Path.To.Param}` for other parameters of configuration, `$$` is replaced with `$`.
+// References to parameters are supported in string values (strings, slices and maps of strings) only,
+// which are interpolated after loading from all sources, including config file.
+// Other values are interpolated before parsing, using environment variables, values of config file are interpolated
+// before decoding.
+func (ci *ConfigInfo) SetInterpolation(enabled bool) {
+	ci.interpolation = enabled
+}
+
+func (ci *ConfigInfo) interpolationEnabled(param *ParamInfo) bool {
+	if value := param.Tag.Get("interpolate"); value != "" {
+		enabled, err := parseBool(value)
+		return err != nil || enabled
+	}
+	return ci.interpolation
+}
+
+// setRawValue parses text value into the field, interpolating environment variables for non-string parameters
+func (ci *ConfigInfo) setRawValue(param *ParamInfo, field reflect.Value, value string) error {
+	if ci.interpolationEnabled(param) && !isStringValue(param.Type) {
+		var err error
+		if value, err = ci.expandEnv(value); err != nil {
+			return err
+		}
+	}
+
+	return parseFieldValue(field, value)
+}
+
+// expandEnv expands references to environment variables, references to parameters are errors
+func (ci *ConfigInfo) expandEnv(text string) (string, error) {
+	return expandVariables(text, func(name string) (string, bool, error) {
+		if _, found := ci.params.ByPath(name); found {
+			return "", false, fmt.Errorf("reference to parameter %s is supported in string values only", name)
+		}
+		value, found := ci.lookupEnv(name)
+		return value, found, nil
+	})
+}
+
+// interpolateNodes expands environment variables in values of config file document for non-string parameters
+// with enabled interpolation, returns true if anything is changed
+func (ci *ConfigInfo) interpolateNodes(root *yaml.Node) (bool, error) {
+	var changed bool
+	for idx := range ci.params {
+		param := &ci.params[idx]
+		if param.FileKey == "" || !ci.interpolationEnabled(param) || isStringValue(param.Type) {
+			continue
+		}
+		mapping, pos := findMappingEntry(root, strings.Split(param.FileKey, "."))
+		if mapping == nil {
+			continue
+		}
+		nodeChanged, err := ci.interpolateNode(mapping.Content[pos+1])
+		if err != nil {
+			return false, newFieldError(fieldErrorInterpolate, param, LoadSourceFile, "", err)
+		}
+		changed = changed || nodeChanged
+	}
+	return changed, nil
+}
+
+// interpolateNode expands environment variables in scalar values of the node and its children.
+// Tags of changed scalars are resolved again, so `${PORT}` becomes a number.
+func (ci *ConfigInfo) interpolateNode(node *yaml.Node) (bool, error) {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		value, err := ci.expandEnv(node.Value)
+		if err != nil || value == node.Value {
+			return false, err
+		}
+		node.Value, node.Tag, node.Style = value, "", 0
+		return true, nil
+	case yaml.SequenceNode, yaml.MappingNode:
+		var changed bool
+		for idx, child := range node.Content {
+			if node.Kind == yaml.MappingNode && idx%2 == 0 {
+				continue // keys are not interpolated
+			}
+			childChanged, err := ci.interpolateNode(child)
+			if err != nil {
+				return false, err
+			}
+			changed = changed || childChanged
+		}
+		return changed, nil
+	default:
+		return false, nil
+	}
+}
+
+// isStringValue checks that the type is a string, a pointer to string, or a slice or a map of strings
+func isStringValue(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.String:
+		return true
+	case reflect.Ptr, reflect.Slice, reflect.Map:
+		return t.Elem().Kind() == reflect.String
+	default:
+		return false
+	}
+}
+
+// Interpolate expands variables references in string values of parameters with enabled interpolation.
+// Load calls it after loading from all sources, it has to be called explicitly after LoadInOrder and TryLoadConfigFile.
+//   - config - a pointer to structure where the configuration is loaded
+func (ci *ConfigInfo) Interpolate(config any) error {
+	rv := reflect.ValueOf(config)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("value is not a pointer to struct")
+	}
+	rv = rv.Elem()
+
+	const (
+		notVisited byte = iota
+		visiting
+		visited
+	)
+	states := make([]byte, len(ci.params))
+	var (
+		chain   []string // paths of parameters being resolved, for cycle reporting
+		failed  = -1     // index of parameter with the first failure
+		resolve func(idx int) error
+	)
+
+	lookup := func(name string) (string, bool, error) {
+		for idx := range ci.params {
+			if ci.params[idx].Path != name {
+				continue
+			}
+			if err := resolve(idx); err != nil {
+				return "", false, err
+			}
+			field := reflect.Indirect(rv.FieldByIndex(ci.params[idx].index))
+			if !field.IsValid() {
+				return "", false, nil
+			}
+			return fmt.Sprint(field.Interface()), true, nil
+		}
+		value, found := ci.lookupEnv(name)
+		return value, found, nil
+	}
+
+	resolve = func(idx int) error {
+		param := &ci.params[idx]
+		switch states[idx] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("interpolation cycle: %s -> %s", strings.Join(chain, " -> "), param.Path)
+		}
+		if !ci.interpolationEnabled(param) || !isStringValue(param.Type) {
+			states[idx] = visited
+			return nil
+		}
+
+		states[idx] = visiting
+		chain = append(chain, param.Path)
+		err := expandStringValue(rv.FieldByIndex(param.index), func(text string) (string, error) {
+			return expandVariables(text, lookup)
+		})
+		chain = chain[:len(chain)-1]
+		states[idx] = visited
+		if err != nil && failed < 0 {
+			failed = idx
+		}
+
+		return err
+	}
+
+	for idx := range ci.params {
+		if err := resolve(idx); err != nil {
+			return newFieldError(fieldErrorInterpolate, &ci.params[failed], ci.params[failed].Source, "", err)
+		}
+	}
+
+	return nil
+}
+
+// expandStringValue applies `expand` to string, pointer to string, or to elements of slice or map of strings
+func expandStringValue(field reflect.Value, expand func(string) (string, error)) error {
+	switch field.Kind() {
+	case reflect.String:
+		value, err := expand(field.String())
+		if err != nil {
+			return err
+		}
+		field.SetString(value)
+	case reflect.Ptr:
+		if !field.IsNil() {
+			return expandStringValue(field.Elem(), expand)
+		}
+	case reflect.Slice:
+		if field.IsNil() {
+			return nil
+		}
+		result := reflect.MakeSlice(field.Type(), field.Len(), field.Len())
+		for i := 0; i < field.Len(); i++ {
+			value, err := expand(field.Index(i).String())
+			if err != nil {
+				return err
+			}
+			result.Index(i).SetString(value)
+		}
+		field.Set(result)
+	case reflect.Map:
+		if field.IsNil() {
+			return nil
+		}
+		result := reflect.MakeMapWithSize(field.Type(), field.Len())
+		iter := field.MapRange()
+		for iter.Next() {
+			value, err := expand(iter.Value().String())
+			if err != nil {
+				return err
+			}
+			result.SetMapIndex(iter.Key(), reflect.ValueOf(value).Convert(field.Type().Elem()))
+		}
+		field.Set(result)
+	}
+
+	return nil
+}
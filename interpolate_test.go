@@ -0,0 +1,182 @@
+package appconfig
+
+import (
+	"os"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigInfo_Interpolate(t *testing.T) {
+	t.Parallel()
+	type testCfg struct {
+		Home    string `default:"${PATH}/app"`
+		Port    int    `default:"${NOT_EXISTING_VAR_FOR_TEST:-8080}"`
+		URL     string `default:"http://localhost:${Port}/${HTTP.Name}"`
+		HTTP    struct{ Name *string }
+		Dirs    []string
+		Labels  map[string]string
+		Raw     string `default:"${PATH}" interpolate:"false"`
+		Escaped string `default:"$$PATH"`
+	}
+	name := "${Home}"
+	cfg := testCfg{
+		HTTP:   struct{ Name *string }{Name: &name},
+		Dirs:   []string{"${Home}/a", "b"},
+		Labels: map[string]string{"k": "${Port}"},
+	}
+
+	ci, err := NewConfigInfo(&cfg, "")
+	require.NoError(t, err)
+	ci.SetInterpolation(true)
+	require.NoError(t, ci.LoadInOrder(&cfg, LoadSourceDefaults))
+	require.NoError(t, ci.Interpolate(&cfg))
+
+	home := os.Getenv("PATH") + "/app"
+	assert.Equal(t, home, cfg.Home)
+	assert.Equal(t, 8080, cfg.Port)
+	assert.Equal(t, "http://localhost:8080/"+home, cfg.URL)
+	assert.Equal(t, home, *cfg.HTTP.Name)
+	assert.Equal(t, []string{home + "/a", "b"}, cfg.Dirs)
+	assert.Equal(t, map[string]string{"k": "8080"}, cfg.Labels)
+	assert.Equal(t, "${PATH}", cfg.Raw)
+	assert.Equal(t, "$PATH", cfg.Escaped)
+
+	require.Error(t, ci.Interpolate(cfg))
+}
+
+func TestConfigInfo_InterpolateOptIn(t *testing.T) {
+	t.Parallel()
+	type testCfg struct {
+		Enabled  string `default:"${PATH}" interpolate:"true"`
+		Disabled string `default:"${PATH}"`
+	}
+	cfg := testCfg{}
+	ci, err := NewConfigInfo(&cfg, "")
+	require.NoError(t, err)
+	require.NoError(t, ci.LoadInOrder(&cfg, LoadSourceDefaults))
+	require.NoError(t, ci.Interpolate(&cfg))
+
+	assert.Equal(t, os.Getenv("PATH"), cfg.Enabled)
+	assert.Equal(t, "${PATH}", cfg.Disabled)
+}
+
+func TestConfigInfo_InterpolateErrors(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		cfg     any
+		loadErr string
+		err     string
+	}{
+		{
+			name: "cycle",
+			cfg: &struct {
+				A string `default:"${B}"`
+				B string `default:"x${C}"`
+				C string `default:"${A}"`
+			}{},
+			err: "can't interpolate value of C: interpolation cycle: A -> B -> C -> A",
+		},
+		{
+			name: "self reference",
+			cfg: &struct {
+				A string `default:"${A}"`
+			}{},
+			err: "can't interpolate value of A: interpolation cycle: A -> A",
+		},
+		{
+			name: "invalid reference",
+			cfg: &struct {
+				A string `default:"${B}"`
+				B string `default:"${unterminated"`
+			}{},
+			err: "can't interpolate value of B: unterminated variable reference",
+		},
+		{
+			name: "parameter reference in non-string value",
+			cfg: &struct {
+				A string `default:"1"`
+				B int    `default:"${A}"`
+			}{},
+			loadErr: "can't parse default value `${A}` for B: reference to parameter A is supported in string values only",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ci, err := NewConfigInfo(tt.cfg, "")
+			require.NoError(t, err)
+			ci.SetInterpolation(true)
+
+			err = ci.LoadInOrder(tt.cfg, LoadSourceDefaults)
+			if tt.loadErr != "" {
+				require.ErrorContains(t, err, tt.loadErr)
+				return
+			}
+			require.NoError(t, err)
+			require.ErrorContains(t, ci.Interpolate(tt.cfg), tt.err)
+		})
+	}
+}
+
+func TestConfigInfo_InterpolateFile(t *testing.T) {
+	t.Parallel()
+	type testCfg struct {
+		ConfigBase `yaml:"-"`
+		Name       string
+		Port       int
+		Ratio      float64
+		Ports      []int
+		Limits     map[string]int
+		Raw        string `interpolate:"false"`
+	}
+	fsys := fstest.MapFS{
+		"app.yaml": {Data: []byte("name: app-${PORT}\nport: ${PORT}\nratio: '${RATIO:-0.5}'\nports: ['${PORT}', 2]\n" +
+			"limits: {a: $PORT}\nraw: ${PORT}\n")},
+		"ref.yaml": {Data: []byte("port: ${Name}\n")},
+	}
+	tests := []struct {
+		name    string
+		file    string
+		strict  bool
+		wantErr string
+	}{
+		{name: "loose", file: "app.yaml"},
+		{name: "strict", file: "app.yaml", strict: true},
+		{
+			name:    "parameter reference",
+			file:    "ref.yaml",
+			wantErr: "can't interpolate value of Port: reference to parameter Name is supported in string values only",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := &testCfg{}
+			ci, err := NewConfigInfo(cfg, "")
+			require.NoError(t, err)
+			ci.SetInterpolation(true)
+			ci.SetStrict(tt.strict)
+			ci.SetArgs([]string{"--config=" + tt.file})
+			ci.SetEnv(map[string]string{"PORT": "90"})
+			ci.SetFS(fsys)
+
+			err = ci.Load(cfg)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "app-90", cfg.Name)
+			assert.Equal(t, 90, cfg.Port)
+			assert.InDelta(t, 0.5, cfg.Ratio, 0)
+			assert.Equal(t, []int{90, 2}, cfg.Ports)
+			assert.Equal(t, map[string]int{"a": 90}, cfg.Limits)
+			assert.Equal(t, "${PORT}", cfg.Raw)
+		})
+	}
+}
@@ -1,38 +1,23 @@
 package appconfig
 
-import "errors"
+import (
+	"context"
+	"errors"
+)
 
 var (
-	ErrStopExpected = errors.New(`a stop is expected`)
-	ErrHelpShown    = errors.Join(ErrStopExpected, errors.New("help shown"))
-	ErrExampleShown = errors.Join(ErrStopExpected, errors.New("example shown"))
+	ErrStopExpected    = errors.New(`a stop is expected`)
+	ErrHelpShown       = errors.Join(ErrStopExpected, errors.New("help shown"))
+	ErrExampleShown    = errors.Join(ErrStopExpected, errors.New("example shown"))
+	ErrCompletionShown = errors.Join(ErrStopExpected, errors.New("completion script shown"))
 )
 
 // Load - loads field values from defaults, then from environment, when from flags, when from config, if specified
 //   - config - a pointer to structure where the configuration is planned to be loaded
-func Load[T any, PT interface{ *T }](receiver PT, envPrefix string) (errResult error) {
-	ci, err := NewConfigInfo(receiver, envPrefix)
-	if err != nil {
-		return err
-	}
-
-	if err = ci.Load(receiver); err != nil {
-		return err
-	}
-
-	if ci.HasHelpFlag() {
-		ci.ShowHelp()
-		errResult = errors.Join(errResult, ErrHelpShown)
-	}
-
-	if ci.HasExampleFlag() {
-		if errLocal := ci.ShowExample(receiver); errLocal != nil {
-			return errLocal
-		}
-		errResult = errors.Join(errResult, ErrExampleShown)
-	}
-
-	return errResult
+//
+// It is a shortcut for NewLoader(WithEnvPrefix(envPrefix)).Load, use Loader for other settings.
+func Load[T any, PT interface{ *T }](receiver PT, envPrefix string) error {
+	return NewLoader(WithEnvPrefix(envPrefix)).Load(context.Background(), receiver)
 }
 
 // MustLoad - try to Load configuration, and panics if error!=nil
@@ -41,3 +26,9 @@ func MustLoad[T any, PT interface{ *T }](receiver PT, envPrefix string) {
 		panic(err)
 	}
 }
+
+// LoadOrExit - loads configuration as Load does, exits with code 0 after printing help, example or completion script,
+// and with code 2 after printing error of loading, see Loader.LoadOrExit
+func LoadOrExit[T any, PT interface{ *T }](receiver PT, envPrefix string) {
+	NewLoader(WithEnvPrefix(envPrefix)).LoadOrExit(context.Background(), receiver)
+}
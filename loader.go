@@ -0,0 +1,301 @@
+package appconfig
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"slices"
+)
+
+// Hook is called after configuration is loaded from all sources and interpolated, before validation.
+// It can be used to compute values or to check them.
+type Hook func(ctx context.Context, config any) error
+
+// Loader loads configuration with its own settings, so different configurations in one process
+// don't depend on package-level variables
+type Loader struct {
+	envPrefix     string
+	order         []Source
+	naming        NamingStrategy
+	strict        bool
+	output        io.Writer
+	errOutput     io.Writer
+	returnOutput  bool
+	args          []string
+	lookupEnv     func(name string) (string, bool)
+	fsys          fs.FS
+	validation    bool
+	validators    []func(config any) error
+	hooks         []Hook
+	interpolation bool
+	helpOptions   *HelpOptions
+	httpOptions   HTTPSourceOptions
+	logger        *slog.Logger
+}
+
+// LoaderOption sets an option of Loader
+type LoaderOption func(l *Loader)
+
+// NewLoader creates a loader. Without options it loads the same way as Load:
+// sources are DefaultLoadOrder followed by config file, names of env and flags are built by DefaultNaming,
+// the result is validated, help, example and completion script are written to os.Stdout.
+func NewLoader(opts ...LoaderOption) *Loader {
+	l := &Loader{
+		order:      append(slices.Clone(DefaultLoadOrder), LoadSourceFile),
+		naming:     DefaultNaming(),
+		validation: true,
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// WithEnvPrefix sets a common prefix for environment variables
+func WithEnvPrefix(envPrefix string) LoaderOption {
+	return func(l *Loader) { l.envPrefix = envPrefix }
+}
+
+// WithSources sets sources and their order, see LoadInOrder. Config file is loaded only if LoadSourceFile is listed.
+func WithSources(order ...Source) LoaderOption {
+	return func(l *Loader) { l.order = slices.Clone(order) }
+}
+
+// WithNaming sets strategy of building names of environment variables and flags
+func WithNaming(naming NamingStrategy) LoaderOption {
+	return func(l *Loader) { l.naming = naming }
+}
+
+// WithStrict enables or disables strict decoding of config file, see ConfigInfo.SetStrict
+func WithStrict(strict bool) LoaderOption {
+	return func(l *Loader) { l.strict = strict }
+}
+
+// WithOutput sets writer for help, config example and completion script, see ConfigInfo.SetOutput
+func WithOutput(w io.Writer) LoaderOption {
+	return func(l *Loader) { l.output = w }
+}
+
+// WithReturnedOutput enables or disables returning of help, config example and completion script
+// in *HelpRequestedError instead of writing them to the output
+func WithReturnedOutput(enabled bool) LoaderOption {
+	return func(l *Loader) { l.returnOutput = enabled }
+}
+
+// WithErrorOutput sets writer for errors, printed by LoadOrExit, instead of os.Stderr
+func WithErrorOutput(w io.Writer) LoaderOption {
+	return func(l *Loader) { l.errOutput = w }
+}
+
+// WithArgs sets command-line arguments (without program name), see ConfigInfo.SetArgs
+func WithArgs(args []string) LoaderOption {
+	return func(l *Loader) { l.args = args }
+}
+
+// WithEnv sets map of environment variables, see ConfigInfo.SetEnv
+func WithEnv(env map[string]string) LoaderOption {
+	return WithLookupEnv(func(name string) (string, bool) {
+		value, found := env[name]
+		return value, found
+	})
+}
+
+// WithLookupEnv sets function to look up environment variables, see ConfigInfo.SetLookupEnv
+func WithLookupEnv(lookup func(name string) (string, bool)) LoaderOption {
+	return func(l *Loader) { l.lookupEnv = lookup }
+}
+
+// WithFS sets filesystem to read config file and env-file from, see ConfigInfo.SetFS
+func WithFS(fsys fs.FS) LoaderOption {
+	return func(l *Loader) { l.fsys = fsys }
+}
+
+// WithValidation enables or disables validation of loaded configuration by tags and validators
+func WithValidation(enabled bool) LoaderOption {
+	return func(l *Loader) { l.validation = enabled }
+}
+
+// WithValidator adds a validator, called after validation by tags
+func WithValidator(validate func(config any) error) LoaderOption {
+	return func(l *Loader) { l.validators = append(l.validators, validate) }
+}
+
+// WithHook adds a hook, called after loading, before validation
+func WithHook(hook Hook) LoaderOption {
+	return func(l *Loader) { l.hooks = append(l.hooks, hook) }
+}
+
+// WithInterpolation enables or disables variables interpolation, see ConfigInfo.SetInterpolation
+func WithInterpolation(enabled bool) LoaderOption {
+	return func(l *Loader) { l.interpolation = enabled }
+}
+
+// WithHelpOptions sets options of help output, see ConfigInfo.SetHelpOptions
+func WithHelpOptions(opts HelpOptions) LoaderOption {
+	return func(l *Loader) { l.helpOptions = &opts }
+}
+
+// WithHTTPSourceOptions sets options of loading config by URL, see ConfigInfo.SetHTTPSourceOptions
+func WithHTTPSourceOptions(opts HTTPSourceOptions) LoaderOption {
+	return func(l *Loader) { l.httpOptions = opts }
+}
+
+// WithLogger sets logger for warnings about deprecated parameters, see ConfigInfo.SetLogger
+func WithLogger(logger *slog.Logger) LoaderOption {
+	return func(l *Loader) { l.logger = logger }
+}
+
+// NewConfigInfo creates ConfigInfo for `config` with settings of the loader
+//   - config - any structure or a pointer to it where the configuration is planned to be loaded
+func (l *Loader) NewConfigInfo(config any) (*ConfigInfo, error) {
+	ci, err := newConfigInfo(config, l.envPrefix, l.naming)
+	if err != nil {
+		return nil, err
+	}
+	ci.SetArgs(l.args)
+	ci.SetLookupEnv(l.lookupEnv)
+	ci.SetFS(l.fsys)
+	ci.SetOutput(l.output)
+	ci.SetInterpolation(l.interpolation)
+	ci.SetHTTPSourceOptions(l.httpOptions)
+	ci.SetStrict(l.strict)
+	ci.SetLogger(l.logger)
+	if l.helpOptions != nil {
+		ci.SetHelpOptions(*l.helpOptions)
+	}
+	return ci, nil
+}
+
+// Load loads configuration from sources, runs hooks and validates the result.
+// If help, example or completion script is requested, it is written to the output,
+// and the corresponding error (ErrHelpShown, ErrExampleShown or ErrCompletionShown) is returned,
+// or it is returned in *HelpRequestedError, if WithReturnedOutput is set.
+//   - config - a pointer to structure where the configuration is planned to be loaded
+func (l *Loader) Load(ctx context.Context, config any) error {
+	_, err := l.loadWithInfo(ctx, config)
+	return err
+}
+
+// LoadCommand does the same as Load, and returns full name of the command, selected by command-line arguments,
+// e.g. "db migrate", empty if no command is selected, see ConfigInfo.Command
+//   - config - a pointer to structure where the configuration is planned to be loaded
+func (l *Loader) LoadCommand(ctx context.Context, config any) (string, error) {
+	ci, err := l.loadWithInfo(ctx, config)
+	if ci == nil {
+		return "", err
+	}
+	return ci.Command(), err
+}
+
+// loadWithInfo does the same as Load, and returns info of the loaded configuration
+func (l *Loader) loadWithInfo(ctx context.Context, config any) (_ *ConfigInfo, errResult error) {
+	ci, err := l.NewConfigInfo(config)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = l.load(ctx, ci, config); err != nil {
+		return nil, err
+	}
+
+	var output *bytes.Buffer
+	if l.returnOutput {
+		output = &bytes.Buffer{}
+		ci.SetOutput(output)
+	}
+
+	if ci.HasHelpFlag() {
+		ci.ShowHelp()
+		errResult = errors.Join(errResult, ErrHelpShown)
+	}
+
+	if ci.HasExampleFlag() {
+		if errLocal := ci.ShowExample(config); errLocal != nil {
+			return nil, errLocal
+		}
+		errResult = errors.Join(errResult, ErrExampleShown)
+	}
+
+	if ci.HasCompletionFlag() {
+		if errLocal := ci.ShowCompletion(ci.CompletionShell()); errLocal != nil {
+			return nil, errLocal
+		}
+		errResult = errors.Join(errResult, ErrCompletionShown)
+	}
+
+	if errResult != nil && output != nil {
+		errResult = &HelpRequestedError{text: output.String(), err: errResult}
+	}
+
+	return ci, errResult
+}
+
+// osExit is replaced in tests
+var osExit = os.Exit
+
+// LoadOrExit loads configuration as Load does, and exits like the standard flag package:
+// with code 0 after printing help, config example or completion script,
+// with code 2 after printing error of loading to os.Stderr (or the writer, set by WithErrorOutput)
+//   - config - a pointer to structure where the configuration is planned to be loaded
+func (l *Loader) LoadOrExit(ctx context.Context, config any) {
+	err := l.Load(ctx, config)
+	if err == nil {
+		return
+	}
+
+	if errors.Is(err, ErrStopExpected) {
+		var helpErr *HelpRequestedError
+		if errors.As(err, &helpErr) {
+			_, _ = io.WriteString(l.writer(), helpErr.Text())
+		}
+		osExit(0)
+		return
+	}
+
+	errOutput := l.errOutput
+	if errOutput == nil {
+		errOutput = os.Stderr
+	}
+	_, _ = fmt.Fprintln(errOutput, err)
+	osExit(2)
+}
+
+func (l *Loader) writer() io.Writer {
+	if l.output != nil {
+		return l.output
+	}
+	return os.Stdout
+}
+
+// load loads configuration, described by `ci`, without showing help, example or completion script
+func (l *Loader) load(ctx context.Context, ci *ConfigInfo, config any) error {
+	if err := ci.load(ctx, config, l.order, false); err != nil {
+		return err
+	}
+
+	for _, hook := range l.hooks {
+		if err := hook(ctx, config); err != nil {
+			return err
+		}
+	}
+
+	if !l.validation || ci.HasHelpFlag() || ci.HasExampleFlag() || ci.HasCompletionFlag() {
+		return nil
+	}
+
+	if err := ci.Validate(config); err != nil {
+		return err
+	}
+	for _, validate := range l.validators {
+		if err := validate(config); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
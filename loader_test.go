@@ -0,0 +1,234 @@
+package appconfig
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type loaderTestCfg struct {
+	ConfigBase
+	DBHost string `default:"localhost"`
+	Port   int    `required:"true"`
+	URL    string
+}
+
+func TestLoader_Load(t *testing.T) {
+	t.Parallel()
+	fsys := fstest.MapFS{
+		"app.yaml":     {Data: []byte("port: 80\n")},
+		"unknown.yaml": {Data: []byte("port: 80\nunknown: 1\n")},
+	}
+
+	tests := []struct {
+		name     string
+		opts     []LoaderOption
+		expected loaderTestCfg
+		wantErr  string
+	}{
+		{
+			name:     "args, env and fs",
+			opts:     []LoaderOption{WithArgs([]string{"--config=app.yaml"}), WithEnvPrefix("APP"), WithEnv(map[string]string{"APP_DB_HOST": "db"}), WithFS(fsys)},
+			expected: loaderTestCfg{ConfigBase: ConfigBase{ConfigFile: "app.yaml"}, DBHost: "db", Port: 80},
+		},
+		{
+			name:     "sources without config file",
+			opts:     []LoaderOption{WithArgs([]string{"--config=app.yaml", "--port=81"}), WithFS(fsys), WithSources(LoadSourceDefaults, LoadSourceFlags)},
+			expected: loaderTestCfg{ConfigBase: ConfigBase{ConfigFile: "app.yaml"}, DBHost: "localhost", Port: 81},
+		},
+		{
+			name: "naming",
+			opts: []LoaderOption{
+				WithEnv(map[string]string{"DBHOST": "db", "PORT": "82"}),
+				WithNaming(NamingStrategy{EnvName: strings.ToUpper, FlagName: strings.ToLower}),
+			},
+			expected: loaderTestCfg{DBHost: "db", Port: 82},
+		},
+		{
+			name:    "strict",
+			opts:    []LoaderOption{WithArgs([]string{"--config=unknown.yaml"}), WithFS(fsys), WithStrict(true)},
+			wantErr: "field unknown not found",
+		},
+		{
+			name:     "not strict",
+			opts:     []LoaderOption{WithArgs([]string{"--config=unknown.yaml"}), WithFS(fsys)},
+			expected: loaderTestCfg{ConfigBase: ConfigBase{ConfigFile: "unknown.yaml"}, DBHost: "localhost", Port: 80},
+		},
+		{
+			name:    "validation",
+			opts:    []LoaderOption{WithArgs([]string{})},
+			wantErr: "invalid value of Port: value is required",
+		},
+		{
+			name:     "validation disabled",
+			opts:     []LoaderOption{WithArgs([]string{}), WithValidation(false)},
+			expected: loaderTestCfg{DBHost: "localhost"},
+		},
+		{
+			name: "validator",
+			opts: []LoaderOption{WithArgs([]string{"--port=80"}), WithValidator(func(config any) error {
+				if config.(*loaderTestCfg).Port < 1024 {
+					return errors.New("privileged port")
+				}
+				return nil
+			})},
+			wantErr: "privileged port",
+		},
+		{
+			name: "hooks",
+			opts: []LoaderOption{
+				WithArgs([]string{"--port=80"}),
+				WithHook(func(_ context.Context, config any) error {
+					cfg := config.(*loaderTestCfg)
+					cfg.URL = "http://" + cfg.DBHost
+					return nil
+				}),
+			},
+			expected: loaderTestCfg{DBHost: "localhost", Port: 80, URL: "http://localhost"},
+		},
+		{
+			name: "hook error",
+			opts: []LoaderOption{WithArgs([]string{"--port=80"}), WithHook(func(context.Context, any) error {
+				return errors.New("hook failed")
+			})},
+			wantErr: "hook failed",
+		},
+		{
+			name:     "interpolation",
+			opts:     []LoaderOption{WithArgs([]string{"--port=80", "--url=http://${DBHost}"}), WithInterpolation(true)},
+			expected: loaderTestCfg{DBHost: "localhost", Port: 80, URL: "http://localhost"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := loaderTestCfg{}
+			opts := append([]LoaderOption{WithEnv(nil)}, tt.opts...)
+			err := NewLoader(opts...).Load(context.Background(), &cfg)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestLoader_Output(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		args     []string
+		wantErr  error
+		contains string
+	}{
+		{name: "help", args: []string{"--help"}, wantErr: ErrHelpShown, contains: "Usage: app [flags]"},
+		{name: "example", args: []string{"--example=env"}, wantErr: ErrExampleShown, contains: "DB_HOST=localhost"},
+		{name: "completion", args: []string{"--completion=bash"}, wantErr: ErrCompletionShown, contains: "complete -F"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			out := bytes.Buffer{}
+			loader := NewLoader(
+				WithArgs(tt.args),
+				WithEnv(nil),
+				WithOutput(&out),
+				WithHelpOptions(HelpOptions{Usage: "Usage: app [flags]"}),
+			)
+			err := loader.Load(context.Background(), &loaderTestCfg{})
+			require.ErrorIs(t, err, tt.wantErr)
+			require.ErrorIs(t, err, ErrStopExpected)
+			assert.Contains(t, out.String(), tt.contains)
+		})
+	}
+}
+
+func TestLoader_Errors(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, NewLoader(WithArgs([]string{})).Load(ctx, &loaderTestCfg{}), context.Canceled)
+
+	require.Error(t, NewLoader().Load(context.Background(), new(int)))
+}
+
+func TestNewHolderWithLoader(t *testing.T) {
+	t.Parallel()
+	env := map[string]string{"PORT": "80"}
+	h, err := NewHolderWithLoader[loaderTestCfg](NewLoader(WithArgs([]string{}), WithLookupEnv(func(name string) (string, bool) {
+		value, found := env[name]
+		return value, found
+	})))
+	require.NoError(t, err)
+	assert.Equal(t, 80, h.Get().Port)
+
+	env = map[string]string{"PORT": "81"}
+	require.NoError(t, h.Reload())
+	assert.Equal(t, 81, h.Get().Port)
+}
+
+func TestLoader_ReturnedOutput(t *testing.T) {
+	t.Parallel()
+	out := bytes.Buffer{}
+	loader := NewLoader(WithArgs([]string{"--help"}), WithEnv(nil), WithOutput(&out), WithReturnedOutput(true))
+	err := loader.Load(context.Background(), &loaderTestCfg{})
+
+	var helpErr *HelpRequestedError
+	require.ErrorAs(t, err, &helpErr)
+	require.ErrorIs(t, err, ErrHelpShown)
+	require.ErrorIs(t, err, ErrStopExpected)
+	assert.Equal(t, ErrHelpShown.Error(), helpErr.Error())
+	assert.Contains(t, helpErr.Text(), "List or program parameters")
+	assert.Empty(t, out.String())
+
+	err = NewLoader(WithArgs([]string{"--port=80"}), WithEnv(nil), WithReturnedOutput(true)).Load(context.Background(), &loaderTestCfg{})
+	require.NoError(t, err)
+}
+
+func TestLoader_LoadOrExit(t *testing.T) {
+	var exitCode int
+	osExitSrc := osExit
+	osExit = func(code int) { exitCode = code }
+	defer func() { osExit = osExitSrc }()
+
+	tests := []struct {
+		name     string
+		opts     []LoaderOption
+		exitCode int
+		out      string
+		errOut   string
+	}{
+		{name: "loaded", opts: []LoaderOption{WithArgs([]string{"--port=80"})}, exitCode: -1},
+		{name: "help", opts: []LoaderOption{WithArgs([]string{"--help"})}, exitCode: 0, out: "List or program parameters"},
+		{name: "returned help", opts: []LoaderOption{WithArgs([]string{"--help"}), WithReturnedOutput(true)}, exitCode: 0, out: "List or program parameters"},
+		{name: "error", opts: []LoaderOption{WithArgs([]string{"--port=http"})}, exitCode: 2, errOut: "can't parse flag value `http` for Port"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exitCode = -1
+			out, errOut := bytes.Buffer{}, bytes.Buffer{}
+			opts := append([]LoaderOption{WithEnv(nil), WithOutput(&out), WithErrorOutput(&errOut)}, tt.opts...)
+			NewLoader(opts...).LoadOrExit(context.Background(), &loaderTestCfg{})
+
+			assert.Equal(t, tt.exitCode, exitCode)
+			if tt.out != "" {
+				assert.Contains(t, out.String(), tt.out)
+			} else {
+				assert.Empty(t, out.String())
+			}
+			if tt.errOut != "" {
+				assert.Contains(t, errOut.String(), tt.errOut)
+			} else {
+				assert.Empty(t, errOut.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package appconfig
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LoadFromMap loads values of parameters from the map, e.g. received from admin API.
+// Keys are paths of parameters (`HTTP.Address`) or their keys in config file (`http.address`),
+// nested maps are accepted too: {"http": {"address": ":8080"}}. Strings are parsed as values of flags,
+// other values are converted as values of config file. Loaded parameters get LoadSourceMap source,
+// other parameters are not changed. Unknown keys are errors.
+//   - config - a pointer to structure where the configuration is planned to be loaded
+func (ci *ConfigInfo) LoadFromMap(config any, values map[string]any) error {
+	rv := reflect.ValueOf(config)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("value is not a pointer to struct")
+	}
+	rv = rv.Elem()
+
+	errs := ci.loadMap(rv, "", values)
+	ci.updateMagicValues(rv)
+	return errors.Join(errs...)
+}
+
+func (ci *ConfigInfo) loadMap(rv reflect.Value, prefix string, values map[string]any) []error {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys) // errors are reported in stable order
+
+	var errs []error
+	for _, key := range keys {
+		value := values[key]
+		key = addPrefix(key, prefix, ".")
+		if param := ci.paramByMapKey(key); param != nil {
+			if err := ci.setMapValue(param, rv.FieldByIndex(param.index), value); err != nil {
+				errs = append(errs, newFieldError(fieldErrorParse, param, LoadSourceMap, fmt.Sprint(value), err))
+				continue
+			}
+			param.Source = LoadSourceMap
+			continue
+		}
+		if nested, ok := value.(map[string]any); ok {
+			errs = append(errs, ci.loadMap(rv, key, nested)...)
+			continue
+		}
+		errs = append(errs, fmt.Errorf("unknown parameter `%s`", key))
+	}
+	return errs
+}
+
+// paramByMapKey finds parameter by its path or key in config file, returns nil if not found
+func (ci *ConfigInfo) paramByMapKey(key string) *ParamInfo {
+	if param := ci.params.paramByPath(key); param != nil {
+		return param
+	}
+	for idx := range ci.params {
+		if ci.params[idx].FileKey == key {
+			return &ci.params[idx]
+		}
+	}
+	return nil
+}
+
+// setMapValue parses strings as text values, other values are converted through YAML, as values of config file
+func (ci *ConfigInfo) setMapValue(param *ParamInfo, field reflect.Value, value any) error {
+	if text, ok := value.(string); ok {
+		return ci.setRawValue(param, field, text)
+	}
+	if value != nil && reflect.TypeOf(value).AssignableTo(field.Type()) {
+		field.Set(reflect.ValueOf(value))
+		return nil
+	}
+
+	data, err := yaml.Marshal(value)
+	if err != nil {
+		return err
+	}
+	result := reflect.New(field.Type())
+	if err = yaml.Unmarshal(data, result.Interface()); err != nil {
+		return errors.New(strings.TrimPrefix(err.Error(), "yaml: "))
+	}
+	field.Set(result.Elem())
+	return nil
+}
+
+// ToMap returns values of parameters, which can be loaded from config file, by their keys in config file,
+// e.g. {"http.address": ":8080"}. Pointers are dereferenced, values of secret parameters are not masked.
+// The result can be loaded back by LoadFromMap.
+//   - config - any structure or a pointer to it, of the same type as used for ConfigInfo creation
+func (ci *ConfigInfo) ToMap(config any) (map[string]any, error) {
+	rv := reflect.ValueOf(config)
+	if rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, errors.New("value is not a struct or pointer to struct")
+	}
+
+	result := make(map[string]any)
+	for idx := range ci.params {
+		param := &ci.params[idx]
+		if param.FileKey == "" {
+			continue
+		}
+		field := rv.FieldByIndex(param.index)
+		if field.Kind() == reflect.Ptr {
+			if field.IsNil() {
+				result[param.FileKey] = nil
+				continue
+			}
+			field = field.Elem()
+		}
+		result[param.FileKey] = field.Interface()
+	}
+	return result, nil
+}
@@ -0,0 +1,127 @@
+package appconfig
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mapTestCfg struct {
+	ConfigBase
+	Name string `default:"app"`
+	HTTP struct {
+		Address string
+		Timeout time.Duration
+		Hosts   []string
+	}
+	Labels map[string]string
+	Limit  *int
+	Token  string `secret:"true" yaml:"api_token"`
+}
+
+func TestConfigInfo_LoadFromMap(t *testing.T) {
+	t.Parallel()
+	limit := 10
+	tests := []struct {
+		name     string
+		values   map[string]any
+		expected func(cfg *mapTestCfg)
+		wantErr  []string
+	}{
+		{
+			name: "flat keys by path and file key",
+			values: map[string]any{
+				"HTTP.Address": ":8080",
+				"api_token":    "t",
+				"Limit":        "10",
+			},
+			expected: func(cfg *mapTestCfg) {
+				cfg.HTTP.Address = ":8080"
+				cfg.Token = "t"
+				cfg.Limit = &limit
+			},
+		},
+		{
+			name: "nested maps and typed values",
+			values: map[string]any{
+				"http":   map[string]any{"address": ":9090", "hosts": []any{"a", "b"}, "timeout": time.Minute},
+				"labels": map[string]any{"env": "prod"},
+				"limit":  10,
+			},
+			expected: func(cfg *mapTestCfg) {
+				cfg.HTTP.Address = ":9090"
+				cfg.HTTP.Hosts = []string{"a", "b"}
+				cfg.HTTP.Timeout = time.Minute
+				cfg.Labels = map[string]string{"env": "prod"}
+				cfg.Limit = &limit
+			},
+		},
+		{
+			name:    "unknown and invalid values",
+			values:  map[string]any{"http": map[string]any{"port": 80}, "limit": "many", "HTTP.Hosts": map[string]any{"a": 1}},
+			wantErr: []string{"unknown parameter `http.port`", "can't parse map value `many` for Limit", "HTTP.Hosts"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := &mapTestCfg{Name: "app"}
+			ci, err := NewConfigInfo(cfg, "")
+			require.NoError(t, err)
+
+			err = ci.LoadFromMap(cfg, tt.values)
+			if len(tt.wantErr) > 0 {
+				for _, text := range tt.wantErr {
+					assert.ErrorContains(t, err, text)
+				}
+				return
+			}
+			require.NoError(t, err)
+			expected := &mapTestCfg{Name: "app"}
+			tt.expected(expected)
+			assert.Equal(t, expected, cfg)
+
+			for _, param := range ci.Params() {
+				if param.Path == "Name" {
+					assert.Equal(t, Source(LoadSourceNone), param.Source, "not loaded")
+				}
+				if param.Path == "HTTP.Address" {
+					assert.Equal(t, Source(LoadSourceMap), param.Source)
+				}
+			}
+		})
+	}
+}
+
+func TestConfigInfo_ToMap(t *testing.T) {
+	t.Parallel()
+	limit := 3
+	cfg := &mapTestCfg{Name: "app", Labels: map[string]string{"a": "b"}, Limit: &limit, Token: "t"}
+	cfg.HTTP.Address = ":8080"
+	cfg.HTTP.Timeout = time.Second
+	cfg.ConfigFile = "app.yaml"
+	ci, err := NewConfigInfo(cfg, "")
+	require.NoError(t, err)
+
+	values, err := ci.ToMap(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"name":         "app",
+		"http.address": ":8080",
+		"http.timeout": time.Second,
+		"http.hosts":   []string(nil),
+		"labels":       map[string]string{"a": "b"},
+		"limit":        3,
+		"api_token":    "t",
+	}, values)
+
+	loaded := &mapTestCfg{}
+	require.NoError(t, ci.LoadFromMap(loaded, values))
+	cfg.ConfigFile = ""
+	assert.Equal(t, cfg, loaded)
+
+	_, err = ci.ToMap(42)
+	require.Error(t, err)
+}
@@ -44,3 +44,16 @@ func toSnakeCase(s string) string {
 func toKebabCase(s string) string {
 	return strings.Join(splitCamelCase(s), FlagSeparator)
 }
+
+// NamingStrategy converts names of fields (or values of `env` and `flag` tags) into parts of environment variables
+// and command-line flags names. Full names are joined with EnvSeparator and FlagSeparator,
+// and converted to upper case for environment variables and to lower case for flags.
+type NamingStrategy struct {
+	EnvName  func(name string) string
+	FlagName func(name string) string
+}
+
+// DefaultNaming splits names by case changes: `DBHost` becomes `DB_HOST` env and `--db-host` flag
+func DefaultNaming() NamingStrategy {
+	return NamingStrategy{EnvName: toSnakeCase, FlagName: toKebabCase}
+}
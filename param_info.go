@@ -0,0 +1,87 @@
+package appconfig
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Kind returns the kind of the field type
+func (p *ParamInfo) Kind() reflect.Kind {
+	if p.Type == nil {
+		return reflect.Invalid
+	}
+	return p.Type.Kind()
+}
+
+// Index returns the index sequence of the field, suitable for reflect.Value.FieldByIndex
+func (p *ParamInfo) Index() []int {
+	return slices.Clone(p.index)
+}
+
+// Value returns current value of the parameter in `config`
+//   - config - any structure or a pointer to it, of the same type as used for ConfigInfo creation
+func (p *ParamInfo) Value(config any) (any, error) {
+	rv := reflect.ValueOf(config)
+	if rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, errors.New("value is not a struct or pointer to struct")
+	}
+	for _, i := range p.index {
+		if rv.Kind() != reflect.Struct || i >= rv.NumField() {
+			return nil, errors.New("value type does not match the parameter " + p.Path)
+		}
+		rv = rv.Field(i)
+	}
+	if rv.Type() != p.Type {
+		return nil, errors.New("value type does not match the parameter " + p.Path)
+	}
+
+	return rv.Interface(), nil
+}
+
+// ByPath finds parameter by its path in configuration structure, e.g. "HTTP.Address"
+func (pl ParamList) ByPath(path string) (ParamInfo, bool) {
+	return pl.find(func(p *ParamInfo) bool { return p.Path == path })
+}
+
+// ByEnvName finds parameter by environment variable name, case-insensitive
+func (pl ParamList) ByEnvName(name string) (ParamInfo, bool) {
+	return pl.find(func(p *ParamInfo) bool { return p.EnvName != "" && strings.EqualFold(p.EnvName, name) })
+}
+
+// ByFlagName finds parameter by command-line flag name, with or without leading "--"
+func (pl ParamList) ByFlagName(name string) (ParamInfo, bool) {
+	name = "--" + strings.TrimLeft(name, "-")
+	return pl.find(func(p *ParamInfo) bool { return p.FlagName != "" && strings.EqualFold(p.FlagName, name) })
+}
+
+// paramByPath returns a pointer to the parameter with the path, nil if not found
+func (pl ParamList) paramByPath(path string) *ParamInfo {
+	for idx := range pl {
+		if pl[idx].Path == path {
+			return &pl[idx]
+		}
+	}
+	return nil
+}
+
+func (pl ParamList) find(match func(p *ParamInfo) bool) (ParamInfo, bool) {
+	for idx := range pl {
+		if match(&pl[idx]) {
+			return pl[idx].clone(), true
+		}
+	}
+	return ParamInfo{}, false
+}
+
+func (p *ParamInfo) clone() ParamInfo {
+	result := *p
+	result.index = slices.Clone(p.index)
+	result.Enum = slices.Clone(p.Enum)
+	result.Aliases = slices.Clone(p.Aliases)
+	return result
+}
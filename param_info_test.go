@@ -0,0 +1,135 @@
+package appconfig
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type paramInfoTestCfg struct {
+	Name     string `default:"app" help:"Name of application" required:"true"`
+	Password string `env:"pwd" flag:"pwd" secret:"yes"`
+	HTTP     struct {
+		Address string `default:":8080" yaml:"addr"`
+		Skipped int    `yaml:"-"`
+	}
+}
+
+func TestConfigInfo_Params(t *testing.T) {
+	t.Parallel()
+	ci, err := NewConfigInfo(&paramInfoTestCfg{}, "APP")
+	require.NoError(t, err)
+
+	params := ci.Params()
+	require.Len(t, params, 4)
+
+	assert.Equal(t, "Name", params[0].Path)
+	assert.Equal(t, "name", params[0].FileKey)
+	assert.Equal(t, reflect.String, params[0].Kind())
+	assert.Equal(t, "Name of application", params[0].Tag.Get("help"))
+	assert.True(t, params[0].Required)
+	assert.False(t, params[0].Secret)
+
+	assert.True(t, params[1].Secret)
+	assert.False(t, params[1].Required)
+
+	assert.Equal(t, "http.addr", params[2].FileKey)
+	assert.Equal(t, []int{2, 0}, params[2].Index())
+	assert.Empty(t, params[3].FileKey)
+
+	// read-only view
+	params[0].Path = "changed"
+	params[2].index[0] = 100
+	assert.Equal(t, "Name", ci.params[0].Path)
+	assert.Equal(t, []int{2, 0}, ci.params[2].index)
+}
+
+func TestParamList_Lookup(t *testing.T) {
+	t.Parallel()
+	ci, err := NewConfigInfo(&paramInfoTestCfg{}, "APP")
+	require.NoError(t, err)
+	params := ci.Params()
+
+	p, ok := params.ByPath("HTTP.Address")
+	require.True(t, ok)
+	assert.Equal(t, "APP_HTTP_ADDRESS", p.EnvName)
+
+	p, ok = params.ByEnvName("app_pwd")
+	require.True(t, ok)
+	assert.Equal(t, "Password", p.Path)
+
+	p, ok = params.ByFlagName("http-address")
+	require.True(t, ok)
+	assert.Equal(t, "HTTP.Address", p.Path)
+
+	p, ok = params.ByFlagName("--pwd")
+	require.True(t, ok)
+	assert.Equal(t, "Password", p.Path)
+
+	_, ok = params.ByPath("HTTP")
+	assert.False(t, ok)
+	_, ok = params.ByEnvName("")
+	assert.False(t, ok)
+}
+
+func TestParamInfo_Value(t *testing.T) {
+	t.Parallel()
+	cfg := paramInfoTestCfg{Name: "test"}
+	cfg.HTTP.Address = ":80"
+	ci, err := NewConfigInfo(&cfg, "")
+	require.NoError(t, err)
+	params := ci.Params()
+
+	value, err := params[0].Value(&cfg)
+	require.NoError(t, err)
+	assert.Equal(t, "test", value)
+
+	value, err = params[2].Value(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, ":80", value)
+
+	_, err = params[0].Value(100)
+	require.Error(t, err)
+
+	_, err = params[2].Value(&struct{ A, B, C int }{})
+	require.Error(t, err)
+}
+
+func TestConfigInfo_ParamSource(t *testing.T) {
+	t.Parallel()
+	type testCfg struct {
+		ConfigBase
+		Name  string `default:"app"`
+		Value int
+		Flag  bool
+		Slice []int
+	}
+	cfg := testCfg{ConfigBase: ConfigBase{ConfigFile: "test_cfg.valid"}}
+	ci, err := NewConfigInfo(&cfg, "")
+	require.NoError(t, err)
+
+	require.NoError(t, ci.LoadInOrder(&cfg, LoadSourceDefaults))
+	require.NoError(t, ci.TryLoadConfigFile(&cfg))
+
+	params := ci.Params()
+	sources := map[string]Source{}
+	for _, p := range params {
+		sources[p.Path] = p.Source
+	}
+	assert.Equal(t, map[string]Source{
+		"ConfigBase.ShowHelp":      LoadSourceDefaults,
+		"ConfigBase.PrintExample":  LoadSourceDefaults,
+		"ConfigBase.ExampleFormat": LoadSourceNone,
+		"ConfigBase.ConfigFile":    LoadSourceNone,
+		"ConfigBase.Completion":    LoadSourceNone,
+		"ConfigBase.EnvFile":       LoadSourceNone,
+		"Name":                     LoadSourceFile,
+		"Value":                    LoadSourceFile,
+		"Flag":                     LoadSourceFile,
+		"Slice":                    LoadSourceFile,
+	}, sources)
+	assert.Equal(t, "file", LoadSourceFile.String())
+	assert.Equal(t, "none", LoadSourceNone.String())
+}
@@ -1,9 +1,12 @@
 package appconfig
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 	"strings"
+
+	"gopkg.in/yaml.v3"
 )
 
 func parseFieldValue(field reflect.Value, value string) error {
@@ -39,6 +42,19 @@ func parseFieldValue(field reflect.Value, value string) error {
 			return err
 		}
 		field.SetFloat(floatValue)
+	case reflect.Ptr:
+		elem := reflect.New(field.Type().Elem())
+		if err := parseFieldValue(elem.Elem(), value); err != nil {
+			return err
+		}
+		field.Set(elem)
+	case reflect.Slice, reflect.Map, reflect.Array, reflect.Struct:
+		// composite values are written in YAML flow style or JSON, e.g. `[a, b]` or `{"k": 1}`
+		result := reflect.New(field.Type())
+		if err := yaml.Unmarshal([]byte(value), result.Interface()); err != nil {
+			return errors.New(strings.TrimPrefix(err.Error(), "yaml: "))
+		}
+		field.Set(result.Elem())
 	default:
 		return fmt.Errorf("unsupported field type: %s", field.Kind())
 	}
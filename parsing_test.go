@@ -149,6 +149,8 @@ func TestParseFieldValue(t *testing.T) {
 		{"float32", float32(0), "123.45", float32(123.45), false},
 		{"float64", float64(0), "123.45", float64(123.45), false},
 		{"float_invalid", float64(0), "abc", float64(0), true},
+		{"pointer", (*int)(nil), "123", func() *int { i := 123; return &i }(), false},
+		{"pointer_invalid", (*int)(nil), "abc", (*int)(nil), true},
 		{"unsupported_type", []string{}, "test", []string{}, true},
 	}
 
@@ -0,0 +1,233 @@
+package appconfig
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// JSONSchemaDraft is the JSON Schema dialect produced by JSONSchema
+const JSONSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// isDurationType checks that the type is time.Duration or a pointer to it
+func isDurationType(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t == durationType
+}
+
+// SchemaOptions contains optional settings of JSON Schema generation
+type SchemaOptions struct {
+	ID     string // `$id` of the schema
+	Title  string // `title` of the schema
+	Strict bool   // disallow unknown keys in all sections, otherwise only in sections marked with `strict:"true"`
+}
+
+// JSONSchema produces JSON Schema (draft 2020-12) of config file for `config` structure
+//   - config - any structure or a pointer to it where the configuration is planned to be loaded
+func JSONSchema(config any, opts SchemaOptions) ([]byte, error) {
+	ci, err := NewConfigInfo(config, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return ci.JSONSchema(opts)
+}
+
+// JSONSchema produces JSON Schema (draft 2020-12) of config file, using parameters metadata:
+// descriptions from `help` tags, defaults from `default` tags, and constraints from validation tags
+func (ci *ConfigInfo) JSONSchema(opts SchemaOptions) ([]byte, error) {
+	root := newObjectSchema(opts.Strict)
+	root["$schema"] = JSONSchemaDraft
+	if opts.ID != "" {
+		root["$id"] = opts.ID
+	}
+	if opts.Title != "" {
+		root["title"] = opts.Title
+	}
+
+	sections := map[string]*SectionInfo{}
+	for idx := range ci.sections {
+		if ci.sections[idx].FileKey != "" {
+			sections[ci.sections[idx].FileKey] = &ci.sections[idx]
+		}
+	}
+
+	types := newTypeSchemas()
+	for idx := range ci.params {
+		param := &ci.params[idx]
+		if param.FileKey == "" {
+			continue
+		}
+
+		keys := strings.Split(param.FileKey, ".")
+		parent := root
+		for i := range keys[:len(keys)-1] {
+			parent = childObjectSchema(parent, keys[i], sections[strings.Join(keys[:i+1], ".")], opts.Strict)
+		}
+
+		name := keys[len(keys)-1]
+		parent["properties"].(map[string]any)[name] = param.jsonSchema(types)
+		if param.Required {
+			required, _ := parent["required"].([]string)
+			parent["required"] = append(required, name)
+		}
+	}
+
+	if len(types.defs) > 0 {
+		root["$defs"] = types.defs
+	}
+
+	return json.MarshalIndent(root, "", "  ")
+}
+
+func newObjectSchema(strict bool) map[string]any {
+	result := map[string]any{
+		"type":       "object",
+		"properties": map[string]any{},
+	}
+	if strict {
+		result["additionalProperties"] = false
+	}
+	return result
+}
+
+func childObjectSchema(parent map[string]any, key string, section *SectionInfo, strict bool) map[string]any {
+	properties := parent["properties"].(map[string]any)
+	if child, ok := properties[key].(map[string]any); ok {
+		return child
+	}
+
+	child := newObjectSchema(strict || (section != nil && section.Strict))
+	if section != nil && section.HelpText != "" {
+		child["description"] = section.HelpText
+	}
+	properties[key] = child
+
+	return child
+}
+
+func (p *ParamInfo) jsonSchema(types *typeSchemas) map[string]any {
+	result := types.schema(p.Type)
+	if p.Tag.Get("help") != "" {
+		result["description"] = p.HelpText
+	}
+	if p.Default != "" && !p.Secret {
+		result["default"] = p.typedValue(p.Default)
+	}
+	if len(p.Enum) > 0 {
+		enum := make([]any, 0, len(p.Enum))
+		for _, value := range p.Enum {
+			enum = append(enum, p.typedValue(value))
+		}
+		result["enum"] = enum
+	}
+
+	minKey, maxKey := "minimum", "maximum"
+	switch result["type"] {
+	case "string":
+		minKey, maxKey = "minLength", "maxLength"
+	case "array":
+		minKey, maxKey = "minItems", "maxItems"
+	case "object":
+		minKey, maxKey = "minProperties", "maxProperties"
+	}
+	if isDurationType(p.Type) {
+		return result // limits of durations can't be expressed for their text form
+	}
+	if limit, err := parseFloat(p.Min); p.Min != "" && err == nil {
+		result[minKey] = limit
+	}
+	if limit, err := parseFloat(p.Max); p.Max != "" && err == nil {
+		result[maxKey] = limit
+	}
+
+	return result
+}
+
+// typedValue converts text value to the type of parameter for using in schema, text is returned if not possible.
+// Durations are kept as text, as they are written in config file.
+func (p *ParamInfo) typedValue(text string) any {
+	if isDurationType(p.Type) {
+		return text
+	}
+	value := reflect.New(p.Type).Elem()
+	if err := parseFieldValue(value, text); err != nil {
+		return text
+	}
+	return value.Interface()
+}
+
+// typeSchemas produces schemas of types, recursive types are put into `$defs` and referenced by `$ref`
+type typeSchemas struct {
+	defs      map[string]any
+	visiting  map[reflect.Type]bool // structures, which schemas are being produced
+	recursive map[reflect.Type]bool // structures, which contain themselves
+}
+
+func newTypeSchemas() *typeSchemas {
+	return &typeSchemas{
+		defs:      map[string]any{},
+		visiting:  map[reflect.Type]bool{},
+		recursive: map[reflect.Type]bool{},
+	}
+}
+
+func (ts *typeSchemas) schema(t reflect.Type) map[string]any {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == durationType {
+		return map[string]any{"type": "string"} // e.g. "5s"
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]any{"type": "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return map[string]any{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": ts.schema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": ts.schema(t.Elem())}
+	case reflect.Struct:
+		if ts.visiting[t] {
+			ts.recursive[t] = true
+			return schemaRef(t)
+		}
+		ts.visiting[t] = true
+		defer delete(ts.visiting, t)
+
+		result := newObjectSchema(false)
+		properties := result["properties"].(map[string]any)
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if key, inline := getFileKey(&field); field.IsExported() && key != "" && !inline {
+				properties[key] = ts.schema(field.Type)
+			}
+		}
+		if ts.recursive[t] {
+			ts.defs[t.String()] = result
+			return schemaRef(t)
+		}
+		return result
+	default:
+		return map[string]any{}
+	}
+}
+
+// schemaRef returns reference to the schema of the type in `$defs`, the name is escaped as JSON Pointer
+func schemaRef(t reflect.Type) map[string]any {
+	name := strings.NewReplacer("~", "~0", "/", "~1").Replace(t.String())
+	return map[string]any{"$ref": "#/$defs/" + name}
+}
@@ -0,0 +1,119 @@
+package appconfig
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONSchema(t *testing.T) {
+	t.Parallel()
+	type testCfg struct {
+		ConfigBase `yaml:"-"`
+		Title      string `default:"My App" help:"Name of application" required:"true"`
+		Mode       string `oneof:"dev prod" default:"dev"`
+		HTTP       struct {
+			Address string        `default:":8080" yaml:"addr"`
+			Port    uint16        `min:"1" max:"65535"`
+			Ratio   float64       `default:"0.5"`
+			Timeout time.Duration `default:"5s" min:"1s"`
+		} `help:"HTTP server" strict:"true"`
+		Password string   `default:"hunter2" secret:"true"`
+		Tags     []string `max:"3"`
+		Limits   map[string]int
+		Items    []struct {
+			Name string
+		}
+	}
+
+	data, err := JSONSchema(testCfg{}, SchemaOptions{ID: "https://example.com/app.json", Title: "App"})
+	require.NoError(t, err)
+
+	expected := `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$id": "https://example.com/app.json",
+		"title": "App",
+		"type": "object",
+		"required": ["title"],
+		"properties": {
+			"title": {"type": "string", "description": "Name of application", "default": "My App"},
+			"mode": {"type": "string", "enum": ["dev", "prod"], "default": "dev"},
+			"http": {
+				"type": "object",
+				"description": "HTTP server",
+				"additionalProperties": false,
+				"properties": {
+					"addr": {"type": "string", "default": ":8080"},
+					"port": {"type": "integer", "minimum": 1, "maximum": 65535},
+					"ratio": {"type": "number", "default": 0.5},
+					"timeout": {"type": "string", "default": "5s"}
+				}
+			},
+			"password": {"type": "string"},
+			"tags": {"type": "array", "items": {"type": "string"}, "maxItems": 3},
+			"limits": {"type": "object", "additionalProperties": {"type": "integer"}},
+			"items": {
+				"type": "array",
+				"items": {"type": "object", "properties": {"name": {"type": "string"}}}
+			}
+		}
+	}`
+	assert.JSONEq(t, expected, string(data))
+
+	data, err = JSONSchema(&struct{ A struct{ B int } }{}, SchemaOptions{Strict: true})
+	require.NoError(t, err)
+	var schema map[string]any
+	require.NoError(t, json.Unmarshal(data, &schema))
+	assert.Equal(t, false, schema["additionalProperties"])
+	assert.Equal(t, false, schema["properties"].(map[string]any)["a"].(map[string]any)["additionalProperties"])
+
+	_, err = JSONSchema(100, SchemaOptions{})
+	require.Error(t, err)
+}
+
+type schemaNode struct {
+	Name     string
+	Children []schemaNode
+	Parent   *schemaNode
+}
+
+func TestJSONSchema_RecursiveType(t *testing.T) {
+	t.Parallel()
+	type testCfg struct {
+		Tree  schemaNode
+		Nodes []schemaNode
+	}
+
+	data, err := JSONSchema(testCfg{}, SchemaOptions{})
+	require.NoError(t, err)
+
+	expected := `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"$defs": {
+			"appconfig.schemaNode": {
+				"type": "object",
+				"properties": {
+					"name": {"type": "string"},
+					"children": {"type": "array", "items": {"$ref": "#/$defs/appconfig.schemaNode"}},
+					"parent": {"$ref": "#/$defs/appconfig.schemaNode"}
+				}
+			}
+		},
+		"properties": {
+			"tree": {
+				"type": "object",
+				"properties": {
+					"name": {"type": "string"},
+					"children": {"type": "array", "items": {"$ref": "#/$defs/appconfig.schemaNode"}},
+					"parent": {"$ref": "#/$defs/appconfig.schemaNode"}
+				}
+			},
+			"nodes": {"type": "array", "items": {"$ref": "#/$defs/appconfig.schemaNode"}}
+		}
+	}`
+	assert.JSONEq(t, expected, string(data))
+}
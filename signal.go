@@ -0,0 +1,59 @@
+package appconfig
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// ReloadOnSignal reloads configuration on every received signal (SIGHUP, if signals are not specified)
+// until the context is canceled. Result of every reloading is passed to `onReload`, nil for success.
+func (h *Holder[T]) ReloadOnSignal(ctx context.Context, onReload func(error), signals ...os.Signal) error {
+	return reloadOnSignal(ctx, h.Reload, onReload, signals)
+}
+
+// ReloadOn reloads configuration on every value received from `trigger` until the context is canceled
+// or the channel is closed. Result of every reloading is passed to `onReload`, nil for success.
+// It allows to trigger reloading without real signals, e.g. in tests.
+func (h *Holder[T]) ReloadOn(ctx context.Context, trigger <-chan os.Signal, onReload func(error)) error {
+	return reloadOn(ctx, trigger, h.Reload, onReload)
+}
+
+// ReloadOnSignal does the same as Holder.ReloadOnSignal, and remembers state of config file
+func (w *Watcher[T]) ReloadOnSignal(ctx context.Context, onReload func(error), signals ...os.Signal) error {
+	return reloadOnSignal(ctx, w.Reload, onReload, signals)
+}
+
+// ReloadOn does the same as Holder.ReloadOn, and remembers state of config file
+func (w *Watcher[T]) ReloadOn(ctx context.Context, trigger <-chan os.Signal, onReload func(error)) error {
+	return reloadOn(ctx, trigger, w.Reload, onReload)
+}
+
+func reloadOnSignal(ctx context.Context, reload func() error, onReload func(error), signals []os.Signal) error {
+	if len(signals) == 0 {
+		signals = []os.Signal{syscall.SIGHUP}
+	}
+	trigger := make(chan os.Signal, 1)
+	signal.Notify(trigger, signals...)
+	defer signal.Stop(trigger)
+
+	return reloadOn(ctx, trigger, reload, onReload)
+}
+
+func reloadOn(ctx context.Context, trigger <-chan os.Signal, reload func() error, onReload func(error)) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case _, ok := <-trigger:
+			if !ok {
+				return nil
+			}
+			err := reload()
+			if onReload != nil {
+				onReload(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package appconfig
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatcher_ReloadOn(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(fileName, []byte("port: 80\n"), 0o600))
+	t.Setenv("SIGNAL_TEST_FILE", fileName)
+
+	w, err := NewWatcher[watcherTestCfg]("SIGNAL_TEST", 0)
+	require.NoError(t, err)
+
+	trigger := make(chan os.Signal)
+	results := make(chan error)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- w.ReloadOn(ctx, trigger, func(err error) { results <- err })
+	}()
+
+	require.NoError(t, os.WriteFile(fileName, []byte("port: 81\n"), 0o600))
+	trigger <- syscall.SIGHUP
+	require.NoError(t, <-results)
+	assert.Equal(t, 81, w.Get().Port)
+
+	require.NoError(t, os.WriteFile(fileName, []byte("port: invalid\n"), 0o600))
+	trigger <- syscall.SIGHUP
+	require.Error(t, <-results)
+	assert.Equal(t, 81, w.Get().Port)
+
+	cancel()
+	select {
+	case err = <-done:
+		require.ErrorIs(t, err, context.Canceled)
+	case <-time.After(5 * time.Second):
+		t.Fatal("ReloadOn is not stopped")
+	}
+
+	close(trigger)
+	require.NoError(t, w.ReloadOn(context.Background(), trigger, nil))
+}
+
+func TestWatcher_ReloadOnSignal(t *testing.T) {
+	t.Parallel()
+	w, err := NewWatcher[struct{ Value int }]("", 0)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, w.ReloadOnSignal(ctx, nil), context.DeadlineExceeded)
+}
@@ -0,0 +1,69 @@
+package appconfig
+
+import (
+	"context"
+	"fmt"
+)
+
+// Source is a source of parameter values, used in LoadInOrder.
+// Besides built-in sources, custom sources have to implement ValueSource or DecodeSource.
+type Source interface {
+	// String returns the name of the source, used in error messages
+	String() string
+}
+
+// ValueSource provides text values of parameters one by one, e.g. a key-value storage.
+// Values are parsed the same way as environment variables and flags.
+type ValueSource interface {
+	Source
+	// Lookup returns text value of the parameter, found is false if the source has no value for it
+	Lookup(param *ParamInfo) (value string, found bool, err error)
+}
+
+// DecodeSource decodes values into the whole configuration structure at once, e.g. a document from a database.
+type DecodeSource interface {
+	Source
+	// Decode loads values into `config` (a pointer to configuration structure), described by `params`,
+	// and returns paths of loaded parameters
+	Decode(config any, params ParamList) (loaded []string, err error)
+}
+
+// contextDecodeSource is a DecodeSource, which supports cancellation of decoding, e.g. HTTPSource
+type contextDecodeSource interface {
+	DecodeContext(ctx context.Context, config any, params ParamList) (loaded []string, err error)
+}
+
+// loadSource is a built-in source
+type loadSource byte
+
+const (
+	LoadSourceNone loadSource = iota // value was not set by any source
+	LoadSourceDefaults
+	LoadSourceFlags
+	LoadSourceEnvs
+	LoadSourceFile    // value was loaded from config file, specified by `use_as_config_file_name` parameter
+	LoadSourceEnvFile // value was loaded from env-file (.env), specified by `use_as_env_file_name` parameter
+	LoadSourceMap     // value was loaded by ConfigInfo.LoadFromMap
+)
+
+// String returns the name of the source
+func (s loadSource) String() string {
+	switch s {
+	case LoadSourceNone:
+		return "none"
+	case LoadSourceDefaults:
+		return "default"
+	case LoadSourceFlags:
+		return "flag"
+	case LoadSourceEnvs:
+		return "env"
+	case LoadSourceFile:
+		return "file"
+	case LoadSourceEnvFile:
+		return "env-file"
+	case LoadSourceMap:
+		return "map"
+	default:
+		return fmt.Sprintf("loadSource(%d)", byte(s))
+	}
+}
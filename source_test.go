@@ -0,0 +1,163 @@
+package appconfig
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// kvSource is a key-value source, keyed by parameter paths
+type kvSource struct {
+	values map[string]string
+	err    error
+}
+
+func (s *kvSource) String() string { return "kv" }
+
+func (s *kvSource) Lookup(param *ParamInfo) (string, bool, error) {
+	if s.err != nil {
+		return "", false, s.err
+	}
+	value, found := s.values[param.Path]
+	return value, found, nil
+}
+
+// jsonSource decodes JSON document into configuration
+type jsonSource struct {
+	data string
+}
+
+func (s jsonSource) String() string { return "json" }
+
+func (s jsonSource) Decode(config any, params ParamList) ([]string, error) {
+	if err := json.Unmarshal([]byte(s.data), config); err != nil {
+		return nil, err
+	}
+	var keys map[string]any
+	if err := json.Unmarshal([]byte(s.data), &keys); err != nil {
+		return nil, err
+	}
+	var loaded []string
+	for _, param := range params {
+		if _, found := keys[param.Path]; found {
+			loaded = append(loaded, param.Path)
+		}
+	}
+	return loaded, nil
+}
+
+type unknownSource struct{}
+
+func (unknownSource) String() string { return "unknown" }
+
+func TestConfigInfo_LoadInOrder_CustomSources(t *testing.T) {
+	t.Parallel()
+	type testCfg struct {
+		ConfigBase
+		Name  string `default:"app"`
+		Port  int    `default:"80"`
+		Hosts []string
+		Debug bool
+	}
+
+	tests := []struct {
+		name     string
+		order    []Source
+		expected testCfg
+		sources  map[string]Source
+		wantErr  string
+	}{
+		{
+			name:     "value source overrides defaults",
+			order:    []Source{LoadSourceDefaults, &kvSource{values: map[string]string{"Port": "8080", "Debug": "yes"}}},
+			expected: testCfg{Name: "app", Port: 8080, Debug: true},
+			sources:  map[string]Source{"Name": LoadSourceDefaults, "Port": &kvSource{}, "Hosts": LoadSourceNone, "Debug": &kvSource{}},
+		},
+		{
+			name: "decode source, then value source",
+			order: []Source{
+				LoadSourceDefaults,
+				jsonSource{data: `{"Name": "json", "Port": 81, "Debug": true}`},
+				&kvSource{values: map[string]string{"Port": "82"}},
+			},
+			expected: testCfg{Name: "json", Port: 82, Debug: true},
+			sources:  map[string]Source{"Name": jsonSource{}, "Port": &kvSource{}, "Hosts": LoadSourceNone, "Debug": jsonSource{}},
+		},
+		{
+			name: "config file from value source",
+			order: []Source{
+				LoadSourceDefaults,
+				&kvSource{values: map[string]string{"ConfigBase.ConfigFile": "app.yaml", "Port": "90"}},
+				LoadSourceFile,
+			},
+			expected: testCfg{ConfigBase: ConfigBase{ConfigFile: "app.yaml"}, Name: "file", Port: 90},
+			sources:  map[string]Source{"Name": LoadSourceFile, "Port": &kvSource{}, "Hosts": LoadSourceNone, "Debug": LoadSourceNone},
+		},
+		{
+			name:    "lookup error",
+			order:   []Source{&kvSource{err: errors.New("connection refused")}},
+			wantErr: "can't get value of ConfigBase.ShowHelp from kv: connection refused",
+		},
+		{
+			name:    "parse error",
+			order:   []Source{&kvSource{values: map[string]string{"Port": "port"}}},
+			wantErr: "can't parse kv value `port` for Port",
+		},
+		{
+			name:    "decode error",
+			order:   []Source{jsonSource{data: `{`}},
+			wantErr: "can't load values from json",
+		},
+		{
+			name:    "unsupported source",
+			order:   []Source{unknownSource{}},
+			wantErr: "unsupported source unknown",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := &testCfg{}
+			ci, err := NewConfigInfo(cfg, "")
+			require.NoError(t, err)
+			ci.SetFS(fstest.MapFS{"app.yaml": {Data: []byte("name: file\n")}})
+
+			err = ci.LoadInOrder(cfg, tt.order...)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, &tt.expected, cfg)
+			for path, source := range tt.sources {
+				param, _ := ci.Params().ByPath(path)
+				assert.Equal(t, source.String(), param.Source.String(), path)
+			}
+		})
+	}
+}
+
+func TestConfigInfo_LoadInOrder_RepeatedLoad(t *testing.T) {
+	t.Parallel()
+	type testCfg struct {
+		ConfigBase
+		Name string
+	}
+	cfg := &testCfg{}
+	ci, err := NewConfigInfo(cfg, "")
+	require.NoError(t, err)
+	ci.SetFS(os.DirFS("."))
+
+	kv := &kvSource{values: map[string]string{"ConfigBase.ConfigFile": "test_cfg.valid"}}
+	require.NoError(t, ci.LoadInOrder(cfg, kv))
+	assert.Equal(t, "test_cfg.valid", ci.configNameParamValue)
+
+	// config name is not kept from previous loading
+	require.NoError(t, ci.LoadInOrder(&testCfg{}))
+	assert.Empty(t, ci.configNameParamValue)
+}
@@ -1,25 +1,77 @@
 package appconfig
 
+import "reflect"
+
 // ConfigBase can be used as embedded field in configuration structure with predefined parameters with autoprocessing:
 //
 // - `help` to use as showing help flag
 //
-// - `example`to use as printing config example flag
+// - `example`to use as printing config example flag, accepts format: `--example=env`, which is stored to ExampleFormat
+//
+// - `example-format` to specify format of config example: yaml (default), json, env or flags
 //
 // - `config` to specify yaml-config file for loading
+//
+// - `completion` to print shell completion script for bash, zsh or fish
+//
+// - `env-file` to specify .env file with environment variables for loading
 type ConfigBase struct {
-	ShowHelp     bool   `yaml:"-" json:"-" env:"-" flag:"help"    default:"false" help:"show this help"      use_as_show_help_flag:"yes"`
-	PrintExample bool   `yaml:"-" json:"-" env:"-" flag:"example" default:"false" help:"show config example" use_as_example_printing_flag:"yes"`
-	ConfigFile   string `yaml:"-" json:"-" env:"-" flag:"config"  default:""     help:"config file to load"  use_as_config_file_name:"yes"`
+	ShowHelp      bool   `yaml:"-" json:"-" env:"-" flag:"help"           default:"false" help:"show this help"                use_as_show_help_flag:"yes"`
+	PrintExample  bool   `yaml:"-" json:"-" env:"-" flag:"example"        default:"false" help:"show config example"           use_as_example_printing_flag:"yes"`
+	ExampleFormat string `yaml:"-" json:"-" env:"-" flag:"example-format" default:""      help:"format of config example"      use_as_example_format:"yes"        oneof:"yaml json env flags"`
+	ConfigFile    string `yaml:"-" json:"-" env:"-" flag:"config"         default:""      help:"config file to load"           use_as_config_file_name:"yes"`
+	Completion    string `yaml:"-" json:"-" env:"-" flag:"completion"     default:""      help:"print shell completion script" use_as_completion_flag:"yes"       oneof:"bash zsh fish"`
+	EnvFile       string `yaml:"-" json:"-" env:"-" flag:"env-file"       default:""      help:"env-file (.env) to load"       use_as_env_file_name:"yes"`
 }
 
+// ParamInfo describes a single configuration parameter
 type ParamInfo struct {
-	Path     string
-	EnvName  string
-	FlagName string
-	HelpText string
-	Default  string
-	index    []int
+	Path       string            // dot-separated path of the field in configuration structure
+	EnvName    string            // environment variable name, empty if not used
+	FlagName   string            // command-line flag name with leading "--", empty if not used
+	FileKey    string            // dot-separated key in config file, empty if not used
+	HelpText   string            // description from `help` tag
+	Default    string            // default value from `default` tag
+	Type       reflect.Type      // type of the field
+	Tag        reflect.StructTag // all tags of the field
+	Required   bool              // field is marked with `required:"true"`
+	Secret     bool              // field is marked with `secret:"true"`, value must not be shown
+	Enum       []string          // allowed values from space-separated `oneof` tag
+	Min        string            // minimal value (or length for strings, slices and maps) from `min` tag
+	Max        string            // maximal value (or length for strings, slices and maps) from `max` tag
+	Source     Source            // source of the current value, filled by loading, LoadSourceNone if value is not loaded
+	Command    string            // full name of the command, e.g. "db migrate", empty for global parameters
+	Arg        string            // position of command-line argument from `arg` tag: number from 0 or ArgRest, empty if not used
+	ArgName    string            // name of command-line argument in usage line, e.g. "INPUT", empty if not used
+	Aliases    []ParamAlias      // old names of the parameter from space-separated `alias` tag
+	Deprecated string            // message from `deprecated` tag, about aliases if any, otherwise the parameter itself is deprecated
+	index      []int
+}
+
+// ParamAlias contains old names of a renamed parameter, which are still loaded with a warning
+type ParamAlias struct {
+	EnvName  string // old environment variable name, empty if not used
+	FlagName string // old command-line flag name with leading "--", empty if not used
+	FileKey  string // old dot-separated key in config file, empty if not used
+}
+
+// SectionInfo describes a nested structure of configuration
+type SectionInfo struct {
+	Path      string // dot-separated path of the field in configuration structure
+	FileKey   string // dot-separated key in config file, empty if not used
+	HelpText  string // description from `help` tag of the structure field
+	Anonymous bool   // structure is embedded
+	Strict    bool   // section is marked with `strict:"true"`, unknown keys are not allowed in config file
+	Command   string // full name of the command, the section belongs to, empty for global sections
+	Default   string // default value of the structure from `default` tag, in YAML flow style or JSON
+}
+
+// CommandInfo describes a subcommand, declared by a nested structure with `cmd` tag
+type CommandInfo struct {
+	Name     string // full name of the command with names of parent commands, e.g. "db migrate"
+	Path     string // dot-separated path of the structure in configuration
+	HelpText string // description from `help` tag of the structure field
 }
 
+// ParamList is a list of configuration parameters in order of the fields declaration
 type ParamList []ParamInfo
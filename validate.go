@@ -0,0 +1,94 @@
+package appconfig
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// Validate checks values of `config` against validation tags of parameters:
+//   - `required:"true"` - value must not be zero
+//   - `oneof:"a b c"` - value must be one of space-separated list
+//   - `min:"1"`, `max:"10"` - limits of numeric value, or length of strings, slices and maps
+//
+// Parameters of not selected commands are not checked. All found violations are returned joined
+func (ci *ConfigInfo) Validate(config any) error {
+	rv := reflect.ValueOf(config)
+	if rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return errors.New("value is not a struct or pointer to struct")
+	}
+
+	var errs []error
+	for idx := range ci.params {
+		if !ci.isParamSelected(&ci.params[idx]) {
+			continue
+		}
+		if err := ci.params[idx].validate(rv.FieldByIndex(ci.params[idx].index)); err != nil {
+			errs = append(errs, newFieldError(fieldErrorValidate, &ci.params[idx], ci.params[idx].Source, "", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (p *ParamInfo) validate(field reflect.Value) error {
+	if field.IsZero() {
+		if p.Required {
+			return errors.New("value is required")
+		}
+		return nil
+	}
+
+	for field.Kind() == reflect.Ptr {
+		field = field.Elem()
+	}
+
+	if len(p.Enum) > 0 && !slices.Contains(p.Enum, fmt.Sprint(field.Interface())) {
+		return fmt.Errorf("value `%v` is not one of %v", field.Interface(), p.Enum)
+	}
+
+	if p.Min == "" && p.Max == "" {
+		return nil
+	}
+
+	var value float64
+	what := "value"
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value = float64(field.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		value = float64(field.Uint())
+	case reflect.Float32, reflect.Float64:
+		value = field.Float()
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		value = float64(field.Len())
+		what = "length"
+	default:
+		return fmt.Errorf("min/max limits are not supported for type %s", field.Type())
+	}
+
+	if p.Min != "" {
+		limit, err := parseFloat(p.Min)
+		if err != nil {
+			return fmt.Errorf("invalid `min` tag value `%s`: %w", p.Min, err)
+		}
+		if value < limit {
+			return fmt.Errorf("%s %v is less than %s", what, value, p.Min)
+		}
+	}
+	if p.Max != "" {
+		limit, err := parseFloat(p.Max)
+		if err != nil {
+			return fmt.Errorf("invalid `max` tag value `%s`: %w", p.Max, err)
+		}
+		if value > limit {
+			return fmt.Errorf("%s %v is greater than %s", what, value, p.Max)
+		}
+	}
+
+	return nil
+}
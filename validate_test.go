@@ -0,0 +1,82 @@
+package appconfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigInfo_Validate(t *testing.T) {
+	t.Parallel()
+	type testCfg struct {
+		Name    string            `required:"true"`
+		Mode    string            `oneof:"dev prod"`
+		Port    int               `min:"1" max:"65535"`
+		Ratio   *float64          `min:"0.5"`
+		Tags    []string          `max:"2"`
+		Labels  map[string]string `min:"1"`
+		Level   uint              `oneof:"1 2 3"`
+		BadTag  int               `min:"abc"`
+		Unknown struct{ A int }
+	}
+	ratio := 0.1
+
+	tests := []struct {
+		name    string
+		cfg     testCfg
+		wantErr []string
+	}{
+		{
+			name: "valid",
+			cfg:  testCfg{Name: "app", Mode: "dev", Port: 80, Tags: []string{"a"}, Labels: map[string]string{"a": "b"}, Level: 2},
+		},
+		{
+			name:    "zero values",
+			wantErr: []string{"invalid value of Name: value is required"},
+		},
+		{
+			name: "all invalid",
+			cfg: testCfg{
+				Mode:   "test",
+				Port:   70000,
+				Ratio:  &ratio,
+				Tags:   []string{"a", "b", "c"},
+				Labels: map[string]string{},
+				Level:  4,
+				BadTag: 1,
+			},
+			wantErr: []string{
+				"invalid value of Name: value is required",
+				"invalid value of Mode: value `test` is not one of [dev prod]",
+				"invalid value of Port: value 70000 is greater than 65535",
+				"invalid value of Ratio: value 0.1 is less than 0.5",
+				"invalid value of Tags: length 3 is greater than 2",
+				"invalid value of Labels: length 0 is less than 1",
+				"invalid value of Level: value `4` is not one of [1 2 3]",
+				"invalid value of BadTag: invalid `min` tag value `abc`",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ci, err := NewConfigInfo(&tt.cfg, "")
+			require.NoError(t, err)
+
+			err = ci.Validate(&tt.cfg)
+			if len(tt.wantErr) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			for _, text := range tt.wantErr {
+				require.ErrorContains(t, err, text)
+			}
+		})
+	}
+
+	ci, err := NewConfigInfo(&testCfg{}, "")
+	require.NoError(t, err)
+	require.Error(t, ci.Validate(100))
+}
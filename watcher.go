@@ -0,0 +1,134 @@
+package appconfig
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"time"
+)
+
+// DefaultWatchInterval is the default interval of config file polling
+const DefaultWatchInterval = 5 * time.Second
+
+// Watcher holds configuration and reloads it, when config file (specified by `use_as_config_file_name` parameter)
+// is changed. Reloading runs the full load pipeline into a fresh structure, validates it and atomically publishes it.
+// If reloading fails, the previous configuration is kept and the error is reported.
+// Config, loaded by URL, is requested on every check, using ETag/If-Modified-Since revalidation.
+type Watcher[T any] struct {
+	Holder[T]
+	interval time.Duration
+
+	// fields below are protected by Holder mutex
+	fileHash []byte
+	onError  func(error)
+}
+
+// NewWatcher loads configuration and creates a watcher for it
+//   - envPrefix - a common prefix for environment variables, as in Load
+//   - interval - interval of config file polling, DefaultWatchInterval if 0
+func NewWatcher[T any](envPrefix string, interval time.Duration) (*Watcher[T], error) {
+	return NewWatcherWithLoader[T](NewLoader(WithEnvPrefix(envPrefix)), interval)
+}
+
+// NewWatcherWithLoader loads configuration with the loader, and creates a watcher for it.
+// The loader is used for reloading too.
+//   - interval - interval of config file polling, DefaultWatchInterval if 0
+func NewWatcherWithLoader[T any](loader *Loader, interval time.Duration) (*Watcher[T], error) {
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+	w := &Watcher[T]{interval: interval}
+	if err := w.init(loader); err != nil {
+		return nil, err
+	}
+	w.fileHash, _ = w.readFileHash()
+
+	return w, nil
+}
+
+// OnError sets handler of reloading errors, occurred in Run. The handler is called without holding the lock.
+func (w *Watcher[T]) OnError(handler func(error)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.onError = handler
+}
+
+// Reload loads configuration as Holder.Reload does, and remembers state of config file
+func (w *Watcher[T]) Reload() error {
+	w.mu.Lock()
+	notify, err := w.reload()
+	w.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	notify()
+
+	return nil
+}
+
+func (w *Watcher[T]) reload() (func(), error) {
+	notify, err := w.Holder.reload()
+	if err != nil {
+		return nil, err
+	}
+	if hash, err := w.readFileHash(); err == nil {
+		w.fileHash = hash
+	}
+	return notify, nil
+}
+
+// Run polls config file until the context is canceled, and reloads configuration when file content is changed
+func (w *Watcher[T]) Run(ctx context.Context) error {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			w.check()
+		}
+	}
+}
+
+func (w *Watcher[T]) check() {
+	w.mu.Lock()
+	onError := w.onError
+	notify, err := w.checkFile()
+	w.mu.Unlock()
+
+	switch {
+	case err != nil && onError != nil:
+		onError(err)
+	case err == nil:
+		notify()
+	}
+}
+
+// checkFile reloads configuration, if config file is changed, returns notification of subscribers as reload does
+func (w *Watcher[T]) checkFile() (func(), error) {
+	// config, loaded by URL, is revalidated by HTTPSource on every check
+	hash, err := w.readFileHash()
+	if err != nil {
+		return nil, err
+	}
+	if bytes.Equal(hash, w.fileHash) && !isConfigURL(w.ci.configNameParamValue) {
+		return func() {}, nil
+	}
+	return w.reload()
+}
+
+// readFileHash returns hash of config file content, nil if config file is not specified or is loaded by URL
+func (w *Watcher[T]) readFileHash() ([]byte, error) {
+	if w.ci.configNameParamValue == "" || isConfigURL(w.ci.configNameParamValue) {
+		return nil, nil
+	}
+	data, err := w.ci.readFile(w.ci.configNameParamValue)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	hash := sha256.Sum256(data)
+	return hash[:], nil
+}
@@ -0,0 +1,118 @@
+package appconfig
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type watcherTestCfg struct {
+	File  string `env:"file" yaml:"-" use_as_config_file_name:"yes"`
+	Name  string `default:"app"`
+	Port  int    `required:"true"`
+	Hosts []string
+}
+
+func TestWatcher(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(fileName, []byte("port: 80\n"), 0o600))
+	t.Setenv("WATCHER_TEST_FILE", fileName)
+
+	w, err := NewWatcher[watcherTestCfg]("WATCHER_TEST", 10*time.Millisecond)
+	require.NoError(t, err)
+	first := w.Get()
+	require.Equal(t, &watcherTestCfg{File: fileName, Name: "app", Port: 80}, first)
+
+	type change struct {
+		oldCfg, newCfg *watcherTestCfg
+		changed        []string
+	}
+	changes := make(chan change, 10)
+	errs := make(chan error, 10)
+	w.Subscribe(func(oldCfg, newCfg *watcherTestCfg, changed []string) {
+		changes <- change{oldCfg, newCfg, changed}
+	})
+	w.OnError(func(err error) { errs <- err })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		assert.ErrorIs(t, w.Run(ctx), context.Canceled)
+	}()
+
+	// valid change
+	require.NoError(t, os.WriteFile(fileName, []byte("port: 81\nhosts: [a, b]\n"), 0o600))
+	select {
+	case c := <-changes:
+		assert.Same(t, first, c.oldCfg)
+		assert.Same(t, w.Get(), c.newCfg)
+		assert.Equal(t, []string{"Port", "Hosts"}, c.changed)
+		assert.Equal(t, 81, c.newCfg.Port)
+		assert.Equal(t, 80, first.Port)
+	case <-time.After(5 * time.Second):
+		t.Fatal("change is not detected")
+	}
+
+	// invalid change keeps previous configuration
+	current := w.Get()
+	require.NoError(t, os.WriteFile(fileName, []byte("port: 0\n"), 0o600))
+	select {
+	case err := <-errs:
+		assert.ErrorContains(t, err, "invalid value of Port: value is required")
+	case <-time.After(5 * time.Second):
+		t.Fatal("error is not reported")
+	}
+	assert.Same(t, current, w.Get())
+
+	cancel()
+	wg.Wait()
+
+	// reload without changes neither publishes new configuration, nor notifies subscribers
+	require.NoError(t, os.WriteFile(fileName, []byte("port: 81\nhosts: [a, b]\n"), 0o600))
+	require.NoError(t, w.Reload())
+	assert.Same(t, current, w.Get())
+	assert.Empty(t, changes)
+
+	require.NoError(t, os.Remove(fileName))
+	require.Error(t, w.Reload())
+}
+
+func TestNewWatcherErrors(t *testing.T) {
+	t.Parallel()
+	_, err := NewWatcher[int]("", 0)
+	require.Error(t, err)
+
+	_, err = NewWatcher[struct {
+		Value int `default:"abc"`
+	}]("", 0)
+	require.Error(t, err)
+}
+
+func TestWatcher_URL(t *testing.T) {
+	var body atomic.Value
+	body.Store("port: 80\n")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(body.Load().(string)))
+	}))
+	defer server.Close()
+	t.Setenv("WATCHER_URL_TEST_FILE", server.URL)
+
+	w, err := NewWatcher[watcherTestCfg]("WATCHER_URL_TEST", 0)
+	require.NoError(t, err)
+	assert.Equal(t, 80, w.Get().Port)
+
+	body.Store("port: 81\n")
+	w.check()
+	assert.Equal(t, 81, w.Get().Port)
+}